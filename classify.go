@@ -0,0 +1,305 @@
+package htmltable
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RowKind is the role of a row within a table, as found by ClassifyRows
+type RowKind int
+
+const (
+	// RowData is an ordinary row of values
+	RowData RowKind = iota
+	// RowEmpty has no text at all
+	RowEmpty
+	// RowHeader is one of the rows at the top of the table naming its columns
+	RowHeader
+	// RowSection is a label without values, heading the rows after it, e.g. "Operating expenses:"
+	RowSection
+	// RowSubtotal sums rows within a section
+	RowSubtotal
+	// RowTotal sums rows at the top level of the table
+	RowTotal
+)
+
+func (k RowKind) String() string {
+	switch k {
+	case RowData:
+		return "data"
+	case RowEmpty:
+		return "empty"
+	case RowHeader:
+		return "header"
+	case RowSection:
+		return "section"
+	case RowSubtotal:
+		return "subtotal"
+	case RowTotal:
+		return "total"
+	}
+	return "unknown"
+}
+
+// RowClass is the classification of a single row
+type RowClass struct {
+	Row  int
+	Kind RowKind
+	// Children are the rows a total or subtotal was checked against
+	Children []int
+	// Checked is set for totals and subtotals whose values could be compared with their children
+	Checked bool
+	// Mismatches lists the columns where a checked total does not equal the sum of its children
+	Mismatches []Mismatch
+}
+
+// Mismatch is a column of a total row whose value differs from the sum of the rows it totals
+type Mismatch struct {
+	Col   int
+	Value float64
+	Sum   float64
+}
+
+// Reconciled reports whether the row was checked and every column summed correctly
+func (c RowClass) Reconciled() bool {
+	return c.Checked && len(c.Mismatches) == 0
+}
+
+// ClassifyRows flags each row of t as a header, section label, total, subtotal, empty or data row.
+//
+// Totals are recognised by labels starting with "Total", labels starting with "Net" that are
+// bold or have a border above their values, and unlabelled rows of values with a border above them.
+// A total nested within a section of the RowTree is a subtotal.
+//
+// The values of totals and subtotals are then checked against the sum of the rows they total:
+// the rows before them in the same section, or for unlabelled totals the data rows directly above them.
+// Totals labelled "Net" may also equal the first of those rows less the others.
+// m is optional, without it styles are unknown.
+func ClassifyRows(t *Table, m *TableMeta) []RowClass {
+	tree := NewRowTree(t, m)
+	classes := make([]RowClass, len(*t))
+	inHeader := true
+	for i, row := range *t {
+		classes[i] = RowClass{Row: i, Kind: RowData}
+		label := ""
+		if len(row) > 0 {
+			label = strings.TrimSpace(row[0])
+		}
+		numeric := numericCols(row)
+		switch {
+		case isEmptyRow(row):
+			classes[i].Kind = RowEmpty
+			continue
+		case inHeader && (label == "" || len(numeric) == 0 && hasText(row[1:]) || isYearRow(row)):
+			classes[i].Kind = RowHeader
+			continue
+		}
+		inHeader = false
+		node := tree.Nodes[i]
+		lower := strings.ToLower(label)
+		emphasised := rowBold(m, i) || rowBorderTop(m, i, numeric)
+		switch {
+		case len(numeric) == 0:
+			if label != "" {
+				classes[i].Kind = RowSection
+			}
+		case isTotalLabel(label),
+			strings.HasPrefix(lower, "net ") && emphasised,
+			label == "" && rowBorderTop(m, i, numeric):
+			classes[i].Kind = RowTotal
+			if node != nil && node.Parent != nil || label == "" {
+				classes[i].Kind = RowSubtotal
+			}
+		}
+	}
+
+	for i := range classes {
+		if classes[i].Kind != RowTotal && classes[i].Kind != RowSubtotal {
+			continue
+		}
+		children := totalChildren(t, tree, classes, i)
+		classes[i].Children = children
+		label := strings.ToLower(strings.TrimSpace((*t)[i][0]))
+		reconcile(t, &classes[i], strings.HasPrefix(label, "net "))
+	}
+	return classes
+}
+
+// Unreconciled returns the totals and subtotals whose values do not equal the sum of their children
+func Unreconciled(classes []RowClass) []RowClass {
+	var found []RowClass
+	for _, c := range classes {
+		if len(c.Mismatches) > 0 {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// totalChildren returns the rows summed by the total at row i
+func totalChildren(t *Table, tree *RowTree, classes []RowClass, i int) []int {
+	node := tree.Nodes[i]
+	if node == nil {
+		// unlabelled totals sum the data rows directly above them
+		var children []int
+		for j := i - 1; j >= 0 && classes[j].Kind == RowData; j-- {
+			children = append([]int{j}, children...)
+		}
+		return children
+	}
+	siblings := tree.Roots
+	if node.Parent != nil {
+		siblings = node.Parent.Children
+	}
+	var children []int
+	for _, s := range siblings {
+		if s == node {
+			break
+		}
+		switch classes[s.Row].Kind {
+		case RowTotal, RowSubtotal:
+			if node.Parent == nil {
+				// top level totals start again after a previous total, e.g. a gross profit line
+				children = []int{s.Row}
+				continue
+			}
+			children = append(children, s.Row)
+		case RowSection:
+			// a section contributes through its own total
+			if len(s.Children) > 0 {
+				last := s.Children[len(s.Children)-1]
+				if k := classes[last.Row].Kind; k == RowTotal || k == RowSubtotal {
+					children = append(children, last.Row)
+				}
+			}
+		case RowData:
+			children = append(children, s.Row)
+		}
+	}
+	return children
+}
+
+// reconcile compares each numeric column of a total with the sum of its children.
+//
+// A difference, e.g. "Net income", may instead equal its first child less the others.
+// As the signs of its children are not known, columns of a difference matching neither are left unchecked.
+func reconcile(t *Table, c *RowClass, difference bool) {
+	if len(c.Children) == 0 {
+		return
+	}
+	row := (*t)[c.Row]
+	for col := 1; col < len(row); col++ {
+		value, ok := ParseNumber(row[col])
+		if !ok || numberDashes[strings.TrimSpace(row[col])] {
+			continue
+		}
+		sum, diff, found := 0.0, 0.0, false
+		for i, child := range c.Children {
+			childRow := (*t)[child]
+			if col >= len(childRow) {
+				continue
+			}
+			if v, ok := ParseNumber(childRow[col]); ok {
+				sum += v
+				if i == 0 {
+					diff += v
+				} else {
+					diff -= v
+				}
+				found = true
+			}
+		}
+		if !found {
+			continue
+		}
+		// allow for rounding to the precision the total is written in
+		tolerance := math.Pow(10, -float64(numberDecimals(row[col]))) + 1e-9
+		switch {
+		case math.Abs(value-sum) <= tolerance:
+			c.Checked = true
+		case difference && math.Abs(value-diff) <= tolerance:
+			c.Checked = true
+		case difference:
+			continue
+		default:
+			c.Checked = true
+			c.Mismatches = append(c.Mismatches, Mismatch{Col: col, Value: value, Sum: sum})
+		}
+	}
+}
+
+// headerRowCount guesses the number of header rows of t,
+// as those before the first row with a number after its label, other than a row of years
+func headerRowCount(t Table) int {
+	for i, row := range t {
+		if len(row) > 0 && strings.TrimSpace(row[0]) != "" && len(numericCols(row)) > 0 && !isYearRow(row) {
+			return i
+		}
+	}
+	return len(t)
+}
+
+// isYearRow reports whether every value after the label is a plausible year, e.g. "Year | 2023 | 2022"
+func isYearRow(row []string) bool {
+	found := false
+	for i := 1; i < len(row); i++ {
+		s := strings.TrimSpace(row[i])
+		if s == "" {
+			continue
+		}
+		if len(s) != 4 || strings.Trim(s, "0123456789") != "" {
+			return false
+		}
+		if year, _ := strconv.Atoi(s); year < 1900 || year > 2100 {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// numericCols returns the columns, other than the label, holding numbers
+func numericCols(row []string) []int {
+	var cols []int
+	for col := 1; col < len(row); col++ {
+		s := strings.TrimSpace(row[col])
+		if _, ok := ParseNumber(s); ok && !numberDashes[s] {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// isEmptyRow reports whether every cell of the row is blank
+func isEmptyRow(row []string) bool {
+	return !hasText(row)
+}
+
+// hasText reports whether any of the cells is not blank
+func hasText(cells []string) bool {
+	for _, s := range cells {
+		if strings.TrimSpace(s) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// rowBold reports whether the label of row i is bold
+func rowBold(m *TableMeta, i int) bool {
+	return m != nil && i < len(m.Cells) && len(m.Cells[i]) > 0 && m.Cells[i][0].Style.Bold()
+}
+
+// rowBorderTop reports whether the numeric cells of row i have a border above them
+func rowBorderTop(m *TableMeta, i int, numeric []int) bool {
+	if m == nil || i >= len(m.Cells) || len(numeric) == 0 {
+		return false
+	}
+	for _, col := range numeric {
+		if col >= len(m.Cells[i]) || !m.Cells[i][col].Style.BorderTop.Visible() {
+			return false
+		}
+	}
+	return true
+}
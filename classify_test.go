@@ -0,0 +1,83 @@
+package htmltable
+
+import "testing"
+
+func TestClassifyRows(t *testing.T) {
+	p, err := ParseString(testTable3, WithFootnotes())
+	assertNoError(t, err)
+	classes := ClassifyRows(p.Tables[0], p.Meta[0])
+	var kinds []RowKind
+	for _, c := range classes {
+		kinds = append(kinds, c.Kind)
+	}
+	assertEqual(t, []RowKind{
+		RowEmpty, RowHeader, RowHeader,
+		RowData, RowData, RowSection, RowData, RowData, RowSubtotal,
+		RowEmpty, RowTotal,
+	}, kinds)
+	assertEqual(t, []int{6, 7}, classes[8].Children)
+	assertEqual(t, true, classes[8].Reconciled())
+	assertEqual(t, []int{3, 4, 8}, classes[10].Children)
+	assertEqual(t, true, classes[10].Reconciled())
+	assertEqual(t, 0, len(Unreconciled(classes)))
+}
+
+func TestClassifyRowsStatement(t *testing.T) {
+	p, err := ParseString(testTableIncomeStatement)
+	assertNoError(t, err)
+	classes := ClassifyRows(p.Tables[0], p.Meta[0])
+	assertEqual(t, RowHeader, classes[0].Kind)
+	assertEqual(t, RowData, classes[1].Kind)
+	assertEqual(t, RowSection, classes[4].Kind)
+	assertEqual(t, RowSubtotal, classes[8].Kind)
+	assertEqual(t, "subtotal", classes[8].Kind.String())
+	assertEqual(t, []int{5, 6}, classes[8].Children)
+	assertEqual(t, true, classes[8].Reconciled())
+	assertEqual(t, RowTotal, classes[11].Kind)
+
+	// net loss subtracts expenses and adds other income, so it cannot be checked without their signs
+	assertEqual(t, false, classes[11].Checked)
+	assertEqual(t, 0, len(Unreconciled(classes)))
+}
+
+func TestClassifyRowsDifference(t *testing.T) {
+	p, err := ParseString(`<table>
+		<tr><td>Year</td><td>2023</td><td>2022</td></tr>
+		<tr><td>Revenue</td><td>300</td><td>250</td></tr>
+		<tr><td>Expenses:</td><td></td><td></td></tr>
+		<tr><td style="padding-left: 13pt">Salaries</td><td>100</td><td>200</td></tr>
+		<tr><td style="padding-left: 13pt">Rent</td><td>20</td><td>80</td></tr>
+		<tr><td style="padding-left: 13pt">Total expenses</td><td>120</td><td>280</td></tr>
+		<tr><td><b>Net income (loss)</b></td><td>180</td><td>(30)</td></tr>
+		<tr><td><b>Net margin</b></td><td>50</td><td>10</td></tr>
+	</table>`)
+	assertNoError(t, err)
+	classes := ClassifyRows(p.Tables[0], p.Meta[0])
+	assertEqual(t, RowHeader, classes[0].Kind)
+	assertEqual(t, RowData, classes[1].Kind)
+	assertEqual(t, RowSubtotal, classes[5].Kind)
+	assertEqual(t, RowTotal, classes[6].Kind)
+	assertEqual(t, []int{1, 5}, classes[6].Children)
+	assertEqual(t, true, classes[6].Reconciled())
+	// a margin is not the difference of the rows above it
+	assertEqual(t, false, classes[7].Checked)
+	assertEqual(t, 0, len(Unreconciled(classes)))
+	assertEqual(t, 1, headerRowCount(*p.Tables[0]))
+}
+
+func TestClassifyRowsUnlabelled(t *testing.T) {
+	p, err := ParseString(`<table>
+		<tr><td>Cash</td><td>10.5</td></tr>
+		<tr><td>Receivables</td><td>4.25</td></tr>
+		<tr><td></td><td style="border-top: 1px solid">14.75</td></tr>
+		<tr><td>Total assets</td><td>20</td></tr>
+	</table>`)
+	assertNoError(t, err)
+	classes := ClassifyRows(p.Tables[0], p.Meta[0])
+	assertEqual(t, RowSubtotal, classes[2].Kind)
+	assertEqual(t, []int{0, 1}, classes[2].Children)
+	assertEqual(t, true, classes[2].Reconciled())
+	assertEqual(t, RowTotal, classes[3].Kind)
+	assertEqual(t, false, classes[3].Reconciled())
+	assertEqual(t, []Mismatch{{Col: 1, Value: 20, Sum: 14.75}}, classes[3].Mismatches)
+}
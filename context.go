@@ -0,0 +1,111 @@
+package htmltable
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// precedingBlocks is how many blocks of text before a table are kept as its context
+const precedingBlocks = 3
+
+// headingElements introduce the section of the document a table belongs to
+var headingElements = map[string]bool{"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true}
+
+// tableCaption returns the text of the <caption> of table node n, if it has one
+func (p *Parser) tableCaption(n *html.Node) string {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "caption" {
+			return p.plainText(c)
+		}
+	}
+	return ""
+}
+
+// tableHeading returns the text of the nearest heading element before table node n
+func (p *Parser) tableHeading(n *html.Node) string {
+	for cur := n; cur != nil; cur = cur.Parent {
+		for s := cur.PrevSibling; s != nil; s = s.PrevSibling {
+			if h := p.lastHeading(s); h != nil {
+				return p.plainText(h)
+			}
+		}
+	}
+	return ""
+}
+
+// lastHeading returns the last visible heading element at or below n
+func (p *Parser) lastHeading(n *html.Node) *html.Node {
+	if n.Type != html.ElementNode || p.isHidden(n) {
+		return nil
+	}
+	if headingElements[n.Data] {
+		return n
+	}
+	for c := n.LastChild; c != nil; c = c.PrevSibling {
+		if h := p.lastHeading(c); h != nil {
+			return h
+		}
+	}
+	return nil
+}
+
+// precedingText returns the blocks of text directly before table node n, closest last,
+// stopping at the previous table
+func (p *Parser) precedingText(n *html.Node) string {
+	var blocks []string
+	stop := false
+	var collect func(n *html.Node)
+	// collect walks n backwards, adding its innermost blocks of text
+	collect = func(n *html.Node) {
+		if stop || len(blocks) >= precedingBlocks {
+			return
+		}
+		switch n.Type {
+		case html.TextNode:
+			if text := strings.Join(strings.Fields(n.Data), " "); text != "" {
+				blocks = append(blocks, text)
+			}
+			return
+		case html.ElementNode:
+		default:
+			return
+		}
+		if skippedElements[n.Data] || p.isHidden(n) {
+			return
+		}
+		if p.kindOf(n) == kindTable {
+			stop = true
+			return
+		}
+		if !hasBlockChild(n) {
+			if text := p.plainText(n); text != "" {
+				blocks = append(blocks, text)
+			}
+			return
+		}
+		for c := n.LastChild; c != nil; c = c.PrevSibling {
+			collect(c)
+		}
+	}
+	for cur := n; cur != nil && !stop && len(blocks) < precedingBlocks; cur = cur.Parent {
+		for s := cur.PrevSibling; s != nil && !stop && len(blocks) < precedingBlocks; s = s.PrevSibling {
+			collect(s)
+		}
+	}
+	// blocks were gathered closest first
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// hasBlockChild reports whether any element below n is a block or a table
+func hasBlockChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (blockElements[c.Data] || hasBlockChild(c)) {
+			return true
+		}
+	}
+	return false
+}
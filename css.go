@@ -0,0 +1,289 @@
+package htmltable
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// parseDeclarations parses a css declaration block, such as the value of a style attribute,
+// into a map of lowercased property names to their values.
+//
+// Later declarations override earlier ones, and !important is stripped.
+func parseDeclarations(s string) map[string]string {
+	decls := map[string]string{}
+	for _, d := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(d, ":")
+		if !ok {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		if name == "" {
+			continue
+		}
+		decls[name] = strings.ToLower(value)
+	}
+	return decls
+}
+
+// stylesheet is the set of rules collected from the <style> blocks of a document.
+//
+// Only simple selectors are supported: tag, class and id, compounds of those (e.g. td.hidden)
+// and the descendant and child combinators. Rules with other selectors are ignored,
+// as are at-rules such as @media.
+type stylesheet struct {
+	rules []cssRule
+}
+
+// cssRule is a single selector of a rule, along with the declarations it applies
+type cssRule struct {
+	selector    []compoundSelector // rightmost compound is last
+	specificity int
+	order       int
+	decls       map[string]string
+}
+
+// compoundSelector is a sequence of simple selectors applying to one element, e.g. div.a#b
+type compoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+	// child is set when this compound must be the parent, rather than any ancestor,
+	// of the compound following it
+	child bool
+}
+
+var (
+	cssCommentRegexp  = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	cssCompoundRegexp = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9-]*|\*)?((?:[.#][-_a-zA-Z0-9]+)*)$`)
+	cssSimpleRegexp   = regexp.MustCompile(`[.#][-_a-zA-Z0-9]+`)
+)
+
+// collectStylesheet gathers the rules of every <style> element below n
+func collectStylesheet(n *html.Node) *stylesheet {
+	s := &stylesheet{}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "style" {
+			var sb strings.Builder
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.TextNode {
+					sb.WriteString(c.Data)
+				}
+			}
+			s.add(sb.String())
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return s
+}
+
+// add parses css source and appends its rules to the stylesheet
+func (s *stylesheet) add(src string) {
+	src = cssCommentRegexp.ReplaceAllString(src, "")
+	for len(src) > 0 {
+		open := strings.IndexByte(src, '{')
+		if open < 0 {
+			return
+		}
+		prelude := strings.TrimSpace(src[:open])
+		// find the matching close brace, so nested at-rule blocks are skipped whole
+		depth, end := 0, -1
+		for i := open; i < len(src) && end < 0; i++ {
+			switch src[i] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			return
+		}
+		body := src[open+1 : end]
+		src = src[end+1:]
+		if strings.HasPrefix(prelude, "@") {
+			continue
+		}
+		decls := parseDeclarations(body)
+		for _, sel := range strings.Split(prelude, ",") {
+			compounds, specificity, ok := parseSelector(sel)
+			if !ok {
+				continue
+			}
+			s.rules = append(s.rules, cssRule{
+				selector:    compounds,
+				specificity: specificity,
+				order:       len(s.rules),
+				decls:       decls,
+			})
+		}
+	}
+}
+
+// parseSelector parses a single complex selector, returning false if it uses unsupported syntax
+func parseSelector(sel string) ([]compoundSelector, int, bool) {
+	fields := strings.Fields(strings.ReplaceAll(sel, ">", " > "))
+	if len(fields) == 0 {
+		return nil, 0, false
+	}
+	var compounds []compoundSelector
+	specificity := 0
+	child := false
+	for _, f := range fields {
+		if f == ">" {
+			if len(compounds) == 0 || child {
+				return nil, 0, false
+			}
+			compounds[len(compounds)-1].child = true
+			child = true
+			continue
+		}
+		child = false
+		m := cssCompoundRegexp.FindStringSubmatch(f)
+		if m == nil {
+			return nil, 0, false
+		}
+		c := compoundSelector{tag: strings.ToLower(m[1])}
+		if c.tag == "*" {
+			c.tag = ""
+		} else if c.tag != "" {
+			specificity++
+		}
+		for _, simple := range cssSimpleRegexp.FindAllString(m[2], -1) {
+			if simple[0] == '#' {
+				c.id = simple[1:]
+				specificity += 10000
+			} else {
+				c.classes = append(c.classes, simple[1:])
+				specificity += 100
+			}
+		}
+		compounds = append(compounds, c)
+	}
+	if child {
+		return nil, 0, false
+	}
+	return compounds, specificity, true
+}
+
+// matches reports whether element n satisfies the compound selector
+func (c compoundSelector) matches(n *html.Node) bool {
+	if n == nil || n.Type != html.ElementNode {
+		return false
+	}
+	if c.tag != "" && c.tag != n.Data {
+		return false
+	}
+	if c.id != "" && getAttr(n, "id") != c.id {
+		return false
+	}
+	if len(c.classes) > 0 {
+		classes := strings.Fields(getAttr(n, "class"))
+		for _, want := range c.classes {
+			found := false
+			for _, have := range classes {
+				if have == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matches reports whether element n is selected by the rule
+func (r cssRule) matches(n *html.Node) bool {
+	last := len(r.selector) - 1
+	if !r.selector[last].matches(n) {
+		return false
+	}
+	return matchAncestors(r.selector[:last], n.Parent)
+}
+
+// matchAncestors matches the remaining compounds, right to left, against n and its ancestors
+func matchAncestors(compounds []compoundSelector, n *html.Node) bool {
+	if len(compounds) == 0 {
+		return true
+	}
+	last := len(compounds) - 1
+	c := compounds[last]
+	for ; n != nil; n = n.Parent {
+		if c.matches(n) && matchAncestors(compounds[:last], n.Parent) {
+			return true
+		}
+		if c.child {
+			return false
+		}
+	}
+	return false
+}
+
+// style returns the declarations applying to element n, from the stylesheet and
+// its inline style attribute, in order of precedence. The stylesheet may be nil,
+// in which case only the inline style is considered.
+//
+// Inherited values are not included.
+func (s *stylesheet) style(n *html.Node) map[string]string {
+	var matched []cssRule
+	if s != nil {
+		for _, r := range s.rules {
+			if r.matches(n) {
+				matched = append(matched, r)
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return parseDeclarations(getAttr(n, "style"))
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].specificity != matched[j].specificity {
+			return matched[i].specificity < matched[j].specificity
+		}
+		return matched[i].order < matched[j].order
+	})
+	decls := map[string]string{}
+	for _, r := range matched {
+		for k, v := range r.decls {
+			decls[k] = v
+		}
+	}
+	for k, v := range parseDeclarations(getAttr(n, "style")) {
+		decls[k] = v
+	}
+	return decls
+}
+
+// getAttr returns the value of the attribute key of n, or "" if not present
+func getAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasAttr reports whether n has the attribute key, regardless of its value
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package htmltable
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestParseDeclarations(t *testing.T) {
+	decls := parseDeclarations(" Display : NONE !important; color: red;;broken; padding-left:13pt")
+	assertEqual(t, map[string]string{
+		"display":      "none",
+		"color":        "red",
+		"padding-left": "13pt",
+	}, decls)
+}
+
+func TestParseSelector(t *testing.T) {
+	for _, tc := range []struct {
+		sel         string
+		ok          bool
+		specificity int
+	}{
+		{"td", true, 1},
+		{".a", true, 100},
+		{"#b", true, 10000},
+		{"div.a.b > td", true, 202},
+		{"table td.x", true, 102},
+		{"*", true, 0},
+		{"a:hover", false, 0},
+		{"td > ", false, 0},
+		{"input[type=text]", false, 0},
+	} {
+		_, specificity, ok := parseSelector(tc.sel)
+		assertEqual(t, tc.ok, ok)
+		assertEqual(t, tc.specificity, specificity)
+	}
+}
+
+func TestStylesheetStyle(t *testing.T) {
+	root, err := html.Parse(strings.NewReader(`<style>
+		td { color: black; font-weight: 400 }
+		.total td { font-weight: 700 }
+		div > td { color: green }
+		#special { color: blue }
+		td { color: red }
+	</style>
+	<table><tr class="total"><td id="special" style="text-align: right">1</td><td>2</td></tr></table>`))
+	assertNoError(t, err)
+	sheet := collectStylesheet(root)
+	tds := findAll(root, "td")
+	assertEqual(t, 2, len(tds))
+	assertEqual(t, map[string]string{
+		"color":       "blue",
+		"font-weight": "700",
+		"text-align":  "right",
+	}, sheet.style(tds[0]))
+	assertEqual(t, map[string]string{
+		"color":       "red",
+		"font-weight": "700",
+	}, sheet.style(tds[1]))
+
+	var noSheet *stylesheet
+	assertEqual(t, map[string]string{"text-align": "right"}, noSheet.style(tds[0]))
+}
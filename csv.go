@@ -0,0 +1,197 @@
+package htmltable
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// CSVOption configures Table.WriteCSV
+type CSVOption func(*csvOptions)
+
+// headerMode is how the header rows of a table are written
+type headerMode int
+
+const (
+	headerAsIs headerMode = iota
+	headerFlat
+	headerNone
+)
+
+type csvOptions struct {
+	delimiter rune
+	crlf      bool
+	bom       bool
+	header    headerMode
+}
+
+// Delimiter sets the field delimiter, which is a comma by default
+func Delimiter(r rune) CSVOption {
+	return func(o *csvOptions) {
+		o.delimiter = r
+	}
+}
+
+// WithCRLF ends lines with \r\n, as RFC 4180 specifies, rather than \n
+func WithCRLF() CSVOption {
+	return func(o *csvOptions) {
+		o.crlf = true
+	}
+}
+
+// WithBOM starts the output with a UTF-8 byte order mark, so that Excel detects the encoding
+func WithBOM() CSVOption {
+	return func(o *csvOptions) {
+		o.bom = true
+	}
+}
+
+// FlatHeader replaces the header rows of the table with a single row of Table.Header
+func FlatHeader() CSVOption {
+	return func(o *csvOptions) {
+		o.header = headerFlat
+	}
+}
+
+// NoHeader leaves out the header rows of the table
+func NoHeader() CSVOption {
+	return func(o *csvOptions) {
+		o.header = headerNone
+	}
+}
+
+func newCSVOptions(opts []CSVOption) *csvOptions {
+	o := &csvOptions{delimiter: ','}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WriteCSV writes the table to w as CSV, quoting values as RFC 4180 specifies.
+// Rows shorter than the widest row are padded with empty values.
+func (t Table) WriteCSV(w io.Writer, opts ...CSVOption) error {
+	o := newCSVOptions(opts)
+	if o.bom {
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return err
+		}
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = o.delimiter
+	cw.UseCRLF = o.crlf
+
+	rows := [][]string(t)
+	switch o.header {
+	case headerFlat:
+		n := lookupHeaderRows(t)
+		rows = append([][]string{t.Header(HeaderRows(n))}, t[n:]...)
+	case headerNone:
+		rows = t[lookupHeaderRows(t):]
+	}
+	width := columnCount(t)
+	for _, row := range rows {
+		if len(row) < width {
+			padded := make([]string, width)
+			copy(padded, row)
+			row = padded
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTSV is same as WriteCSV, but with tabs between values
+func (t Table) WriteTSV(w io.Writer, opts ...CSVOption) error {
+	return t.WriteCSV(w, append([]CSVOption{Delimiter('\t')}, opts...)...)
+}
+
+// TableNames returns a name for each of the parsed tables, unique within the document, for naming
+// the files or sheets they are written to. Names are taken from the caption, else the heading
+// before the table, else its index, e.g. "consolidated-balance-sheets" or "table-3".
+func (p *Parser) TableNames() []string {
+	names := make([]string, len(p.Tables))
+	used := map[string]bool{}
+	for i := range p.Tables {
+		name := ""
+		if i < len(p.Meta) {
+			name = slug(p.Meta[i].Caption)
+			if name == "" {
+				name = slug(p.Meta[i].Heading)
+			}
+			if name == "" {
+				name = fmt.Sprintf("table-%d", p.Meta[i].Index)
+			}
+		} else {
+			name = fmt.Sprintf("table-%d", i)
+		}
+		if used[name] {
+			n := 2
+			for used[fmt.Sprintf("%s-%d", name, n)] {
+				n++
+			}
+			name = fmt.Sprintf("%s-%d", name, n)
+		}
+		used[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// WriteCSVFiles writes each of the parsed tables to its own file in dir, named by TableNames,
+// with a .tsv extension when the delimiter is a tab and .csv otherwise.
+// It returns the paths of the files written.
+func (p *Parser) WriteCSVFiles(dir string, opts ...CSVOption) ([]string, error) {
+	ext := ".csv"
+	if newCSVOptions(opts).delimiter == '\t' {
+		ext = ".tsv"
+	}
+	var paths []string
+	for i, name := range p.TableNames() {
+		path := filepath.Join(dir, name+ext)
+		f, err := os.Create(path)
+		if err != nil {
+			return paths, err
+		}
+		err = p.Tables[i].WriteCSV(f, opts...)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, fmt.Errorf("%s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// slug reduces s to lowercase letters and digits separated by dashes, at most 64 characters long
+func slug(s string) string {
+	var sb strings.Builder
+	dash := false
+	count := 0
+	for _, r := range strings.ToLower(s) {
+		if count >= 64 {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+				count++
+			}
+			sb.WriteRune(r)
+			count++
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return sb.String()
+}
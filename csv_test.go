@@ -0,0 +1,57 @@
+package htmltable
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	ts, err := NewFromString(testTable2)
+	assertNoError(t, err)
+	table := ts[0]
+	(*table)[2][5] = `Market "cap", change`
+
+	var buf bytes.Buffer
+	assertNoError(t, table.WriteCSV(&buf))
+	assertEqual(t, `Date,Added,Added,Removed,Removed,Reason
+Date,Ticker,Security,Ticker,Security,Reason
+"June 21, 2022",KDP,Keurig Dr Pepper,UA/UAA,Under Armour,"Market ""cap"", change"
+"June 21, 2022",ON,ON Semiconductor,IPGP,IPG Photonics,Market capitalization change. [4]
+`, buf.String())
+
+	buf.Reset()
+	assertNoError(t, table.WriteTSV(&buf, FlatHeader(), WithBOM(), WithCRLF()))
+	assertEqual(t, true, strings.HasPrefix(buf.String(), "\ufeffDate\tAdded Ticker\tAdded Security\tRemoved Ticker\tRemoved Security\tReason\r\n"))
+
+	buf.Reset()
+	ragged := Table{{"a", "b"}, {"1"}}
+	assertNoError(t, ragged.WriteCSV(&buf, NoHeader(), Delimiter(';')))
+	assertEqual(t, "1;\n", buf.String())
+}
+
+func TestWriteCSVFiles(t *testing.T) {
+	p, err := ParseString(`<table><tr><td>z</td></tr></table><h2>Index changes</h2>` + testTable2 +
+		`<h2>Notes</h2><table><tr><td>a</td></tr></table><table><tr><td>b</td></tr></table>` +
+		`<table><caption>Balance Sheets</caption><tr><td>x</td></tr></table>`)
+	assertNoError(t, err)
+	assertEqual(t, []string{"table-0", "index-changes", "notes", "notes-2", "balance-sheets"}, p.TableNames())
+
+	dir := t.TempDir()
+	paths, err := p.WriteCSVFiles(dir, Delimiter('\t'))
+	assertNoError(t, err)
+	assertEqual(t, filepath.Join(dir, "balance-sheets.tsv"), paths[4])
+	data, err := os.ReadFile(paths[4])
+	assertNoError(t, err)
+	assertEqual(t, "x\n", string(data))
+
+	p, err = ParseString(`<table><caption>Notes</caption><tr><td>a</td></tr></table>` +
+		`<table><caption>Notes</caption><tr><td>b</td></tr></table>` +
+		`<table><caption>Notes 2</caption><tr><td>c</td></tr></table>` +
+		`<table><caption>Table 4</caption><tr><td>d</td></tr></table>` +
+		`<table><tr><td>e</td></tr></table>`)
+	assertNoError(t, err)
+	assertEqual(t, []string{"notes", "notes-2", "notes-2-2", "table-4", "table-4-2"}, p.TableNames())
+}
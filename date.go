@@ -0,0 +1,120 @@
+package htmltable
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the layouts of dates with month names, tried in order after normalizing the text
+var dateLayouts = []string{
+	"January 2 2006", "Jan 2 2006", "2 January 2006", "2 Jan 2006",
+	"Monday January 2 2006", "Monday Jan 2 2006", "Monday 2 January 2006",
+	"2-Jan-2006", "2-Jan-06", "Jan-2-2006", "2006 January 2", "2006-Jan-02",
+}
+
+// isoLayouts are the layouts of ISO 8601 dates and times
+var isoLayouts = []string{
+	time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02", "2006/01/02", "2006.01.02",
+}
+
+var (
+	// numericDateRegexp matches dates written in numbers, e.g. 09/30/2023, 30.09.23 or 2023-9-30
+	numericDateRegexp = regexp.MustCompile(`^(\d{1,4})([/.-])(\d{1,2})([/.-])(\d{2,4})$`)
+	// ordinalRegexp matches the suffix of ordinal days, e.g. 1st or 30th
+	ordinalRegexp = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)\b`)
+	// sept is an abbreviation of September that the time package does not know
+	septRegexp = regexp.MustCompile(`(?i)\bsept\b`)
+)
+
+// ParseDate parses a date as commonly written in tables: ISO 8601 ("2023-09-30"), with month names
+// ("September 30, 2023", "Sept. 30, 2023", "30 Sep 2023", "30-Sep-23", "Saturday, September 30th, 2023")
+// or in numbers. Numbers separated by slashes or dashes are read month first, as in the US ("09/30/2023"),
+// unless the first cannot be a month, and those separated by dots are read day first, as in Europe ("30.09.2023").
+// Two digit years are taken to be from 1970 to 2069.
+func ParseDate(s string) (time.Time, bool) {
+	return parseDate(s, false)
+}
+
+// ParseDateDayFirst is same as ParseDate, but reads numeric dates day first, as in Europe ("30/09/2023"),
+// unless the first cannot be a day
+func ParseDateDayFirst(s string) (time.Time, bool) {
+	return parseDate(s, true)
+}
+
+func parseDate(s string, dayFirst bool) (time.Time, bool) {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\u00a0", " "))
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range isoLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	if m := numericDateRegexp.FindStringSubmatch(s); m != nil && m[2] == m[4] {
+		return numericDate(m[1], m[3], m[5], dayFirst || m[2] == ".")
+	}
+
+	// reduce month name dates to the layouts: no ordinals, commas or abbreviation dots, single spaces
+	s = ordinalRegexp.ReplaceAllString(s, "$1")
+	s = septRegexp.ReplaceAllString(s, "Sep")
+	s = strings.NewReplacer(",", " ", ".", " ").Replace(s)
+	s = strings.Join(strings.Fields(s), " ")
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// numericDate builds the date of numbers a, b and c, which are read as year, month and day when a is
+// four digits long, and otherwise as day or month, the other and year
+func numericDate(a, b, c string, dayFirst bool) (time.Time, bool) {
+	first, _ := strconv.Atoi(a)
+	second, _ := strconv.Atoi(b)
+	year, _ := strconv.Atoi(c)
+	if len(a) == 4 {
+		if len(c) > 2 {
+			return time.Time{}, false
+		}
+		return validDate(first, second, year)
+	}
+	switch len(c) {
+	case 2:
+		year = expandYear(year)
+	case 4:
+	default:
+		return time.Time{}, false
+	}
+	month, day := first, second
+	if dayFirst {
+		month, day = second, first
+	}
+	if month > 12 && day <= 12 {
+		month, day = day, month
+	}
+	return validDate(year, month, day)
+}
+
+// expandYear expands a two digit year to 1970 to 2069, as the time package does
+func expandYear(year int) int {
+	if year < 70 {
+		return 2000 + year
+	}
+	return 1900 + year
+}
+
+// validDate returns the date, unless the day does not exist in the month
+func validDate(year, month, day int) (time.Time, bool) {
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Day() != day {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,37 @@
+package htmltable
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	sep30 := time.Date(2023, time.September, 30, 0, 0, 0, 0, time.UTC)
+	for _, s := range []string{
+		"2023-09-30", "2023/09/30", "2023-9-30", "September 30, 2023", "Sept. 30, 2023", "Sep 30 2023",
+		"SEPTEMBER 30, 2023", "30 September 2023", "30 Sep 2023", "30-Sep-23", "30-Sep-2023",
+		"Saturday, September 30th, 2023", "09/30/2023", "9/30/23", "30/09/2023", "30.09.2023", "30.9.23",
+		"September 30, 2023",
+	} {
+		d, ok := ParseDate(s)
+		assertEqual(t, true, ok)
+		assertEqual(t, sep30, d)
+	}
+	d, ok := ParseDate("2023-09-30T12:00:00Z")
+	assertEqual(t, true, ok)
+	assertEqual(t, time.Date(2023, time.September, 30, 12, 0, 0, 0, time.UTC), d)
+
+	d, _ = ParseDate("03/04/2023")
+	assertEqual(t, time.March, d.Month())
+	d, _ = ParseDateDayFirst("03/04/2023")
+	assertEqual(t, time.April, d.Month())
+	d, _ = ParseDate("1/2/69")
+	assertEqual(t, 2069, d.Year())
+	d, _ = ParseDate("9/30/99")
+	assertEqual(t, 1999, d.Year())
+
+	for _, s := range []string{"", "2023", "02/30/2023", "13/13/2023", "1,234", "Q3 2023", "09/30-2023"} {
+		_, ok := ParseDate(s)
+		assertEqual(t, false, ok)
+	}
+}
@@ -0,0 +1,272 @@
+package htmltable
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Footnote is a reference marker separated from the text of a cell
+type Footnote struct {
+	// Marker is the reference without surrounding brackets, e.g. "4" for [4] or "1" for (1)
+	Marker string
+	// Target is the id of the element the marker links to, if any
+	Target string
+	// Text is the footnote itself, found elsewhere in the document when parsing WithFootnoteResolution
+	Text string
+}
+
+// WithFootnotes separates footnote markers from cell values into Cell.Footnotes.
+//
+// Markers are taken from <sup> elements holding a short reference such as [4], (1), a or *,
+// or a link to an anchor in the page, and from SEC-style markers such as (1) or (a)
+// following the text of a cell. A single letter is only taken when it links to an anchor or a note
+// in the document begins with it, as it may be an exponent or ordinal suffix.
+func WithFootnotes() Option {
+	return func(p *Parser) {
+		p.footnotes = true
+	}
+}
+
+// WithFootnoteResolution separates footnote markers as WithFootnotes does,
+// and also looks up the text of each footnote in the document.
+//
+// Linked markers are resolved through the id of the element they point to,
+// and others from the first paragraph, list item or row after the cell beginning with the same marker.
+func WithFootnoteResolution() Option {
+	return func(p *Parser) {
+		p.footnotes = true
+		p.resolveFootnotes = true
+	}
+}
+
+var (
+	// footnoteMarkerRegexp matches the text of a <sup> footnote marker, e.g. [4], (1), a, *
+	footnoteMarkerRegexp = regexp.MustCompile(`^(?:\[([^\]\s]{1,8})\]|\(([0-9a-zA-Z]{1,3})\)|([a-z]|[*†‡§]{1,3}))$`)
+	// trailingMarkerRegexp matches an SEC-style (1) or (a) marker following text
+	trailingMarkerRegexp = regexp.MustCompile(`^(.*[^\s(])\s*\(([0-9]{1,2}|[a-z])\)$`)
+	// leadingMarkerRegexp matches footnote text beginning with its marker, e.g. "(1) Includes ..."
+	leadingMarkerRegexp = regexp.MustCompile(`^(?:\(([0-9]{1,2}|[a-z])\)|\[([^\]\s]{1,8})\]|([0-9]{1,2}|[a-z]|[*†‡§]{1,3})[.)]?)\s+(\S.*)$`)
+)
+
+// footnoteMarker returns the footnote described by sup element n, if it is one
+func (p *Parser) footnoteMarker(n *html.Node) (Footnote, bool) {
+	if !p.footnotes || n.Type != html.ElementNode || n.Data != "sup" {
+		return Footnote{}, false
+	}
+	var sb strings.Builder
+	p.getInnerText(n, &sb)
+	text := strings.Join(strings.Fields(sb.String()), "")
+	target := ""
+	var findLink func(n *html.Node)
+	findLink = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			if href := getAttr(n, "href"); strings.HasPrefix(href, "#") && target == "" {
+				target = href[1:]
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			findLink(c)
+		}
+	}
+	findLink(n)
+	if m := footnoteMarkerRegexp.FindStringSubmatch(text); m != nil {
+		// a single letter may be an exponent or ordinal suffix, so it needs a note to refer to
+		if len(m[3]) == 1 && m[3] >= "a" && m[3] <= "z" && target == "" && !p.footnoteIndex.hasNote(m[3]) {
+			return Footnote{}, false
+		}
+		return Footnote{Marker: m[1] + m[2] + m[3], Target: target}, true
+	}
+	if target != "" && text != "" && len(text) <= 8 {
+		return Footnote{Marker: text, Target: target}, true
+	}
+	return Footnote{}, false
+}
+
+// collectFootnotes returns the <sup> footnote markers below cell node n, in document order
+func (p *Parser) collectFootnotes(n *html.Node) []Footnote {
+	var found []Footnote
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if p.isHidden(c) {
+			continue
+		}
+		if f, ok := p.footnoteMarker(c); ok {
+			found = append(found, f)
+			continue
+		}
+		found = append(found, p.collectFootnotes(c)...)
+	}
+	return found
+}
+
+// splitTrailingMarkers removes SEC-style markers such as "(1)" from the end of value,
+// returning the remaining text and the markers in the order they appeared
+func splitTrailingMarkers(value string) (string, []Footnote) {
+	var found []Footnote
+	for {
+		m := trailingMarkerRegexp.FindStringSubmatch(value)
+		// only strip markers following words, so a bare "(5)" stays a negative number
+		if m == nil || !strings.ContainsAny(strings.ToLower(m[1]), "abcdefghijklmnopqrstuvwxyz") {
+			break
+		}
+		value = strings.TrimSpace(m[1])
+		found = append([]Footnote{{Marker: m[2]}}, found...)
+	}
+	return value, found
+}
+
+// cellFootnotes separates the footnotes of cell node n, whose text is value,
+// returning the value without its markers
+func (p *Parser) cellFootnotes(n *html.Node, value string) (string, []Footnote) {
+	footnotes := p.collectFootnotes(n)
+	value, trailing := splitTrailingMarkers(value)
+	footnotes = append(footnotes, trailing...)
+	if p.resolveFootnotes {
+		for i := range footnotes {
+			footnotes[i].Text = p.footnoteText(n, footnotes[i])
+		}
+	}
+	return value, footnotes
+}
+
+// footnoteIndex holds the notes of the document, for recognising and resolving footnotes
+type footnoteIndex struct {
+	ids   map[string]*html.Node
+	order map[*html.Node]int
+	// notes are elements whose text starts with a footnote marker, in document order
+	// as the leaf containers they are taken from are never nested
+	notes []footnoteEntry
+}
+
+type footnoteEntry struct {
+	marker string
+	text   string
+	order  int
+}
+
+// footnoteContainers are the elements whose text may hold a footnote
+var footnoteContainers = map[string]bool{
+	"p": true, "div": true, "li": true, "tr": true, "dd": true, "span": true, "font": true,
+}
+
+// inlineContainers are the footnote containers found within a line, which may hold one of several notes
+var inlineContainers = map[string]bool{"span": true, "font": true}
+
+// buildFootnoteIndex walks the document once to find ids and footnote texts.
+//
+// Only leaf containers are rendered: blocks holding no other container, or only inline ones none of which
+// held a note, and inline containers holding no other container. So each text is rendered at most twice.
+func (p *Parser) buildFootnoteIndex(root *html.Node) {
+	idx := &footnoteIndex{
+		ids:   map[string]*html.Node{},
+		order: map[*html.Node]int{},
+	}
+	// walk returns whether n or its descendants are containers, and whether any of them is a block
+	var walk func(n *html.Node) (bool, bool)
+	walk = func(n *html.Node) (bool, bool) {
+		idx.order[n] = len(idx.order)
+		if n.Type == html.ElementNode {
+			if id := getAttr(n, "id"); id != "" {
+				if _, ok := idx.ids[id]; !ok {
+					idx.ids[id] = n
+				}
+			}
+		}
+		notes := len(idx.notes)
+		containers, blocks := false, false
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			container, block := walk(c)
+			containers = containers || container
+			blocks = blocks || block
+		}
+		if n.Type != html.ElementNode || !footnoteContainers[n.Data] {
+			return containers, blocks
+		}
+		block := !inlineContainers[n.Data]
+		if !blocks && (!containers || block && len(idx.notes) == notes) {
+			idx.addNote(p, n)
+		}
+		return true, blocks || block
+	}
+	walk(root)
+	p.footnoteIndex = idx
+}
+
+// addNote adds container n to the notes if its text starts with a footnote marker
+func (idx *footnoteIndex) addNote(p *Parser, n *html.Node) {
+	// the marker itself is needed, so render without separating footnotes
+	m := leadingMarkerRegexp.FindStringSubmatch(p.plainText(n))
+	if m == nil {
+		return
+	}
+	idx.notes = append(idx.notes, footnoteEntry{
+		marker: m[1] + m[2] + m[3],
+		text:   m[4],
+		order:  idx.order[n],
+	})
+}
+
+// hasNote reports whether the document holds a note beginning with marker
+func (idx *footnoteIndex) hasNote(marker string) bool {
+	if idx == nil {
+		return false
+	}
+	for _, note := range idx.notes {
+		if note.marker == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// footnoteText finds the text of footnote f referenced from cell node n, or "" if not found
+func (p *Parser) footnoteText(n *html.Node, f Footnote) string {
+	idx := p.footnoteIndex
+	if idx == nil {
+		return ""
+	}
+	if f.Target != "" {
+		target, ok := idx.ids[f.Target]
+		if !ok {
+			return ""
+		}
+		// wikipedia style notes keep the text apart from their back links
+		if text := findByClass(target, "reference-text"); text != nil {
+			target = text
+		}
+		return strings.TrimSpace(strings.TrimLeft(p.plainText(target), "^↑ "))
+	}
+	// prefer the first note after the cell, as notes usually follow their table
+	at := idx.order[n]
+	first := ""
+	for _, note := range idx.notes {
+		if note.marker != f.Marker {
+			continue
+		}
+		if note.order > at {
+			return note.text
+		}
+		if first == "" {
+			first = note.text
+		}
+	}
+	return first
+}
+
+// findByClass returns the first element at or below n having class c
+func findByClass(n *html.Node, c string) *html.Node {
+	if n.Type == html.ElementNode {
+		for _, have := range strings.Fields(getAttr(n, "class")) {
+			if have == c {
+				return n
+			}
+		}
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findByClass(child, c); found != nil {
+			return found
+		}
+	}
+	return nil
+}
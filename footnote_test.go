@@ -0,0 +1,95 @@
+package htmltable
+
+import "testing"
+
+func TestFootnotes(t *testing.T) {
+	p, err := ParseString(testTable2, WithFootnotes())
+	assertNoError(t, err)
+	assertEqual(t, "Market capitalization change.", (*p.Tables[0])[2][5])
+	assertEqual(t, []Footnote{{Marker: "4", Target: "cite_note-sp20220603-4"}}, p.Meta[0].Cells[2][5].Footnotes)
+
+	p, err = ParseString(testTable3, WithFootnotes())
+	assertNoError(t, err)
+	assertEqual(t, "Interest income", (*p.Tables[0])[3][0])
+	assertEqual(t, []Footnote{{Marker: "1"}}, p.Meta[0].Cells[3][0].Footnotes)
+	assertEqual(t, "( 3,050 )", (*p.Tables[0])[4][3])
+	assertEqual(t, 0, len(p.Meta[0].Cells[4][3].Footnotes))
+}
+
+func TestFootnoteResolution(t *testing.T) {
+	p, err := ParseString(testTableFootnotes, WithFootnoteResolution(), WithTextMode(TextBlock))
+	assertNoError(t, err)
+	assertEqual(t, *p.Tables[0], Table{
+		{"Revenue", "100"},
+		{"Net loss", "(5)"},
+		{"Other", "7"},
+	})
+	cells := p.Meta[0].Cells
+	assertEqual(t, []Footnote{{Marker: "4", Target: "cite_note-4", Text: "Index announcement."}}, cells[0][0].Footnotes)
+	assertEqual(t, []Footnote{
+		{Marker: "1", Text: "Includes stock-based compensation."},
+		{Marker: "b", Text: "Restated."},
+	}, cells[1][0].Footnotes)
+	assertEqual(t, []Footnote{{Marker: "*", Text: "Unaudited."}}, cells[2][0].Footnotes)
+	assertEqual(t, 0, len(cells[1][1].Footnotes))
+}
+
+func TestFootnoteLetters(t *testing.T) {
+	p, err := ParseString(`<table>
+		<tr><td>Area in m<sup>2</sup></td><td>x<sup>n</sup></td><td>Revenue<sup>a</sup></td><td>2<sup>d</sup> quarter</td></tr>
+	</table>
+	<p>(a) Restated.</p>`, WithFootnotes())
+	assertNoError(t, err)
+	assertEqual(t, Table{{"Area in m 2", "x n", "Revenue", "2 d quarter"}}, *p.Tables[0])
+	assertEqual(t, []Footnote{{Marker: "a"}}, p.Meta[0].Cells[0][2].Footnotes)
+}
+
+func TestFootnoteIndexLeaves(t *testing.T) {
+	p, err := ParseString(`<div>
+		<table><tr><td>Revenue (1)</td><td>Costs (2)</td><td>Other (3)</td></tr></table>
+		<div><p>Notes:</p><div><font>(1) First.</font><font>(2) Second.</font></div></div>
+		<p>(3) <span>Third</span> note.</p>
+	</div>`, WithFootnoteResolution())
+	assertNoError(t, err)
+	cells := p.Meta[0].Cells[0]
+	assertEqual(t, "First.", cells[0].Footnotes[0].Text)
+	assertEqual(t, "Second.", cells[1].Footnotes[0].Text)
+	assertEqual(t, "Third note.", cells[2].Footnotes[0].Text)
+	// the notes are found in the leaf containers only
+	assertEqual(t, 3, len(p.footnoteIndex.notes))
+}
+
+func TestSplitTrailingMarkers(t *testing.T) {
+	for _, tc := range []struct {
+		in, out string
+		markers int
+	}{
+		{"Interest income (1)", "Interest income", 1},
+		{"Net loss(1)(2)", "Net loss", 2},
+		{"Total, net (a) (b)", "Total, net", 2},
+		{"(5)", "(5)", 0},
+		{"( 3,050 )", "( 3,050 )", 0},
+		{"Class (A)", "Class (A)", 0},
+		{"Revenue (in thousands)", "Revenue (in thousands)", 0},
+	} {
+		out, markers := splitTrailingMarkers(tc.in)
+		assertEqual(t, tc.out, out)
+		assertEqual(t, tc.markers, len(markers))
+	}
+}
+
+const testTableFootnotes = `<body>
+<table>
+	<tr><td>Revenue<sup class="reference"><a href="#cite_note-4">[4]</a></sup></td><td>100</td></tr>
+	<tr><td>Net loss<sup>(1)</sup> (b)</td><td>(5)</td></tr>
+	<tr><td>Other<sup>*</sup></td><td>7</td></tr>
+</table>
+<p>(1) Includes stock-based compensation.</p>
+<table>
+	<tr><td>(b)</td><td>Restated.</td></tr>
+</table>
+<div><span>*</span> Unaudited.</div>
+<ol class="references">
+	<li id="cite_note-4"><b><a href="#cite_ref-4">^</a></b> <span class="reference-text">Index announcement.</span></li>
+</ol>
+</body>`
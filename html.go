@@ -0,0 +1,72 @@
+package htmltable
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// HTML renders the table as a minimal <table>, with its header rows in a <thead> and the rest in a <tbody>,
+// and without any of the styling of the original document.
+//
+// With MergeSpans the cells spanning several rows or columns are merged back into a single cell with
+// rowspan and colspan, its caption is kept and header cells outside the header rows are written as <th>.
+// Spans are cut at the end of the <thead>, as HTML does not allow them to cross it.
+func (t Table) HTML(opts ...RenderOption) string {
+	o := newRenderOptions(t, opts)
+	var sb strings.Builder
+	sb.WriteString("<table>\n")
+	if o.meta != nil && o.meta.Caption != "" {
+		sb.WriteString("<caption>" + htmlEscape(o.meta.Caption) + "</caption>\n")
+	}
+	rows := t.segments(o)
+	section := func(tag string, start, end int) {
+		if start >= end {
+			return
+		}
+		sb.WriteString("<" + tag + ">\n")
+		for r := start; r < end; r++ {
+			sb.WriteString("<tr>")
+			for _, seg := range rows[r] {
+				rowspan := 1
+				if cell := o.cell(r, seg.col); cell != nil {
+					if cell.Row != r && r != start {
+						// covered by a cell of a row above
+						continue
+					}
+					if seg.col < len(t[r]) {
+						seg.text = t[r][seg.col]
+					}
+					for r+rowspan < end && o.cell(r+rowspan, seg.col) == cell {
+						rowspan++
+					}
+				}
+				name := "td"
+				if cell := o.cell(r, seg.col); r < o.headerRows || (cell != nil && cell.Header) {
+					name = "th"
+				}
+				sb.WriteString("<" + name)
+				if rowspan > 1 {
+					sb.WriteString(` rowspan="` + strconv.Itoa(rowspan) + `"`)
+				}
+				if seg.span > 1 {
+					sb.WriteString(` colspan="` + strconv.Itoa(seg.span) + `"`)
+				}
+				sb.WriteString(">" + htmlEscape(seg.text) + "</" + name + ">")
+			}
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("</" + tag + ">\n")
+	}
+	section("thead", 0, o.headerRows)
+	section("tbody", o.headerRows, len(rows))
+	sb.WriteString("</table>\n")
+	return sb.String()
+}
+
+var htmlReplacer = strings.NewReplacer("\r\n", "<br>", "\n", "<br>")
+
+// htmlEscape escapes s for html text, keeping its line breaks
+func htmlEscape(s string) string {
+	return htmlReplacer.Replace(html.EscapeString(strings.TrimSpace(s)))
+}
@@ -0,0 +1,49 @@
+package htmltable
+
+import (
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	p, err := ParseString(testTable2)
+	assertNoError(t, err)
+	out := p.Tables[0].HTML(MergeSpans(p.Meta[0]))
+	assertEqual(t, `<table>
+<thead>
+<tr><th rowspan="2">Date</th><th colspan="2">Added</th><th colspan="2">Removed</th><th rowspan="2">Reason</th></tr>
+<tr><th>Ticker</th><th>Security</th><th>Ticker</th><th>Security</th></tr>
+</thead>
+<tbody>
+<tr><td>June 21, 2022</td><td>KDP</td><td>Keurig Dr Pepper</td><td>UA/UAA</td><td>Under Armour</td><td>Market capitalization change. [4]</td></tr>
+<tr><td>June 21, 2022</td><td>ON</td><td>ON Semiconductor</td><td>IPGP</td><td>IPG Photonics</td><td>Market capitalization change. [4]</td></tr>
+</tbody>
+</table>
+`, out)
+
+	// the rendered table parses back to the same values
+	q, err := ParseString(out)
+	assertNoError(t, err)
+	assertEqual(t, p.Tables, q.Tables)
+}
+
+func TestHTMLSpansAndEscaping(t *testing.T) {
+	p, err := ParseString(`<table style="border:1px">
+		<caption>Q&amp;A</caption>
+		<tr><td rowspan="3" style="color:red">Label</td><th>Head</th></tr>
+		<tr><td>a &lt; b</td></tr>
+		<tr><th>Row</th></tr>
+	</table>`, WithTextMode(TextBlock))
+	assertNoError(t, err)
+	assertEqual(t, `<table>
+<caption>Q&amp;A</caption>
+<thead>
+<tr><th>Label</th><th>Head</th></tr>
+</thead>
+<tbody>
+<tr><td rowspan="2">Label</td><td>a &lt; b</td></tr>
+<tr><th>Row</th></tr>
+</tbody>
+</table>
+`, p.Tables[0].HTML(MergeSpans(p.Meta[0]), RenderHeaderRows(1)))
+	assertEqual(t, "<table>\n</table>\n", Table{}.HTML())
+}
@@ -0,0 +1,181 @@
+package htmltable
+
+import (
+	"strings"
+)
+
+// ValueKind is the kind of values a column holds, as reported by InferSchema
+type ValueKind int
+
+// Kinds of values reported by InferSchema
+const (
+	ValueText ValueKind = iota
+	ValueInteger
+	ValueDecimal
+	ValuePercent
+	ValueCurrency
+	ValueDate
+	ValueBoolean
+)
+
+func (k ValueKind) String() string {
+	switch k {
+	case ValueText:
+		return "text"
+	case ValueInteger:
+		return "integer"
+	case ValueDecimal:
+		return "decimal"
+	case ValuePercent:
+		return "percent"
+	case ValueCurrency:
+		return "currency"
+	case ValueDate:
+		return "date"
+	case ValueBoolean:
+		return "boolean"
+	}
+	return "unknown"
+}
+
+// ColumnReport describes the values of a column below the header, as found by InferSchema
+type ColumnReport struct {
+	// Name is the flattened header of the column, made unique within the table
+	Name string
+	Col  int
+	Kind ValueKind
+	// Values and Blank count the values of the column that are not blank and blank,
+	// counting placeholders such as dashes and "n/a" as blank
+	Values int
+	Blank  int
+	// Nullable is set when some of the values are blank
+	Nullable bool
+	// Unparseable is the share of the values that are not blank which cannot be read as Kind, from 0 to 1
+	Unparseable float64
+	// Examples are the first few distinct values that are not blank
+	Examples []string
+}
+
+// maxExamples is the number of examples kept for each column
+const maxExamples = 3
+
+// columnValues counts the values of a column below its header by the kinds they can be read as,
+// for classifying the column
+type columnValues struct {
+	values, blank int
+	// numbers are read with ParseNumber, and are integers when whole and neither decimal nor percent
+	numbers, integers, percents, currencies int
+	dates, booleans                         int
+	examples                                []string
+}
+
+// Thresholds deciding whether the kind matching the most values of a column is the kind of the column
+var (
+	// allValues requires every value that is not blank to match, as for Schema
+	allValues = func(matched, values int) bool { return matched == values }
+	// mostValues requires more than half of the values that are not blank to match, as for InferSchema
+	mostValues = func(matched, values int) bool { return matched*2 > values }
+)
+
+// countColumn reads the values of column col below the header rows of t.
+// Dashes and "n/a" are taken as blanks, as they are written in columns of any kind.
+func countColumn(t Table, headerRows, col int) columnValues {
+	var c columnValues
+	seen := map[string]bool{}
+	for _, row := range t[headerRows:] {
+		value := ""
+		if col < len(row) {
+			value = strings.TrimSpace(row[col])
+		}
+		if value == "" || isPlaceholder(value) {
+			c.blank++
+			continue
+		}
+		c.values++
+		if len(c.examples) < maxExamples && !seen[value] {
+			seen[value] = true
+			c.examples = append(c.examples, value)
+		}
+		if f, ok := ParseNumber(value); ok {
+			c.numbers++
+			percent := strings.Contains(value, "%")
+			switch {
+			case percent:
+				c.percents++
+			case strings.ContainsAny(value, "$€£¥"):
+				c.currencies++
+			}
+			if _, err := toInt(f, 0, 0); err == nil && numberDecimals(value) == 0 && !percent {
+				c.integers++
+			}
+		}
+		if _, ok := ParseDate(value); ok {
+			c.dates++
+		}
+		if _, ok := parseStrictBool(value); ok {
+			c.booleans++
+		}
+	}
+	return c
+}
+
+// kind returns the kind matching the most values, preferring numbers, along with the number of values it matches.
+// The column is text, matching none, when there are no values or the kind does not meet the threshold.
+//
+// A column of numbers is percent when they are all percentages, currency when some of them have a currency symbol
+// and none are percentages, integer when they are all whole and decimal otherwise.
+func (c columnValues) kind(threshold func(matched, values int) bool) (ValueKind, int) {
+	numberKind := ValueDecimal
+	switch {
+	case c.numbers > 0 && c.percents == c.numbers:
+		numberKind = ValuePercent
+	case c.currencies > 0 && c.percents == 0:
+		numberKind = ValueCurrency
+	case c.integers == c.numbers:
+		numberKind = ValueInteger
+	}
+	best, matched := ValueText, 0
+	for _, candidate := range []struct {
+		kind  ValueKind
+		count int
+	}{{numberKind, c.numbers}, {ValueDate, c.dates}, {ValueBoolean, c.booleans}} {
+		if candidate.count > matched {
+			best, matched = candidate.kind, candidate.count
+		}
+	}
+	if matched == 0 || !threshold(matched, c.values) {
+		return ValueText, 0
+	}
+	return best, matched
+}
+
+// InferSchema examines the values of each column of t below its header rows, which are guessed unless given
+// by HeaderRows, and reports the kind of values it holds, how many are blank and the share that do not fit.
+//
+// Columns are classified as for Table.Schema, except that the kind need only match more than half of the values,
+// with the rest reported as unparseable. Numbers are read with ParseNumber, dates with ParseDate,
+// and booleans are true/false, yes/no or checkboxes. Dashes and "n/a" are taken as blanks.
+func InferSchema(t *Table, opts ...LookupOption) []ColumnReport {
+	l := newLookup(*t, opts)
+	width := columnCount(*t)
+	keys := jsonKeys(t.Header(HeaderRows(l.headerRows)), width)
+	reports := make([]ColumnReport, width)
+	for col := range reports {
+		c := countColumn(*t, l.headerRows, col)
+		r := ColumnReport{
+			Name:     keys[col],
+			Col:      col,
+			Values:   c.values,
+			Blank:    c.blank,
+			Nullable: c.blank > 0,
+			Examples: c.examples,
+		}
+		kind, matched := c.kind(mostValues)
+		if kind != ValueText {
+			r.Kind = kind
+			r.Unparseable = float64(c.values-matched) / float64(c.values)
+		}
+		reports[col] = r
+	}
+	return reports
+}
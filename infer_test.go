@@ -0,0 +1,50 @@
+package htmltable
+
+import (
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	table := Table{
+		{"", "2023", "Change", "Filed", "Audited", "Notes"},
+		{"Revenue", "$ 1,200", "12.5%", "2023-01-31", "☒", "see note"},
+		{"Costs", "(300)", "(2.0%)", "Jan 5, 2022", "no", ""},
+		{"Other", "n/a", "—", "pending", "yes", "1"},
+		{"Total", "900.5", "", "", "", ""},
+	}
+	reports := InferSchema(&table, HeaderRows(1))
+	assertEqual(t, 6, len(reports))
+
+	assertEqual(t, "0", reports[0].Name)
+	assertEqual(t, ValueText, reports[0].Kind)
+	assertEqual(t, []string{"Revenue", "Costs", "Other"}, reports[0].Examples)
+
+	assertEqual(t, ColumnReport{
+		Name:        "2023",
+		Col:         1,
+		Kind:        ValueCurrency,
+		Values:      3,
+		Blank:       1,
+		Nullable:    true,
+		Unparseable: 0,
+		Examples:    []string{"$ 1,200", "(300)", "900.5"},
+	}, reports[1])
+
+	// the dash is a null rather than a zero
+	assertEqual(t, ValuePercent, reports[2].Kind)
+	assertEqual(t, true, reports[2].Nullable)
+	assertEqual(t, 2, reports[2].Blank)
+	assertEqual(t, 0.0, reports[2].Unparseable)
+
+	assertEqual(t, ValueDate, reports[3].Kind)
+	assertEqual(t, 1.0/3, reports[3].Unparseable)
+	assertEqual(t, ValueBoolean, reports[4].Kind)
+	assertEqual(t, ValueText, reports[5].Kind)
+	assertEqual(t, 0.0, reports[5].Unparseable)
+	assertEqual(t, "percent", ValuePercent.String())
+
+	ints := Table{{"n"}, {"1"}, {"2,000"}}
+	assertEqual(t, ValueInteger, InferSchema(&ints)[0].Kind)
+	percents := Table{{"n"}, {"1%"}, {"(2.5%)"}}
+	assertEqual(t, ValuePercent, InferSchema(&percents)[0].Kind)
+}
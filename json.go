@@ -0,0 +1,240 @@
+package htmltable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// JSONShape is the layout of a table encoded by Table.WriteJSON
+type JSONShape int
+
+const (
+	// JSONArrays encodes the table as an array of rows, each an array of values
+	JSONArrays JSONShape = iota
+	// JSONObjects encodes each row below the header as an object keyed by the flattened header of each column
+	JSONObjects
+	// JSONColumns encodes the table as an object keyed by the flattened header of each column,
+	// holding the array of values below the header
+	JSONColumns
+)
+
+// WriteJSON writes the table to w in the given shape.
+//
+// For JSONObjects and JSONColumns, keys keep the order of the columns. Columns without a header are keyed
+// by their index, and a repeated header is numbered from its second column, e.g. "Ticker 2".
+// Header rows are guessed unless given by HeaderRows.
+func (t Table) WriteJSON(w io.Writer, shape JSONShape, opts ...LookupOption) error {
+	var buf bytes.Buffer
+	switch shape {
+	case JSONArrays:
+		rows := [][]string(t)
+		if rows == nil {
+			rows = [][]string{}
+		}
+		data, err := json.Marshal(rows)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	case JSONObjects, JSONColumns:
+		l := newLookup(t, opts)
+		keys := jsonKeys(t.Header(HeaderRows(l.headerRows)), columnCount(t))
+		body := t[l.headerRows:]
+		if shape == JSONObjects {
+			buf.WriteByte('[')
+			for i, row := range body {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				values := make([]string, len(keys))
+				copy(values, row)
+				writeJSONObject(&buf, keys, func(col int) interface{} { return values[col] })
+			}
+			buf.WriteByte(']')
+		} else {
+			writeJSONObject(&buf, keys, func(col int) interface{} {
+				values := make([]string, len(body))
+				for i, row := range body {
+					if col < len(row) {
+						values[i] = row[col]
+					}
+				}
+				return values
+			})
+		}
+	default:
+		return fmt.Errorf("unknown JSON shape %d", shape)
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// jsonKeys makes the header names unique, naming columns without a header by their index
+func jsonKeys(header []string, cols int) []string {
+	keys := make([]string, cols)
+	seen := map[string]int{}
+	for col := range keys {
+		key := ""
+		if col < len(header) {
+			key = header[col]
+		}
+		if key == "" {
+			key = strconv.Itoa(col)
+		}
+		seen[key]++
+		if seen[key] > 1 {
+			key = fmt.Sprintf("%s %d", key, seen[key])
+		}
+		keys[col] = key
+	}
+	return keys
+}
+
+// writeJSONObject writes an object with the keys in order, and the value of each column
+func writeJSONObject(buf *bytes.Buffer, keys []string, value func(col int) interface{}) {
+	buf.WriteByte('{')
+	for col, key := range keys {
+		if col > 0 {
+			buf.WriteByte(',')
+		}
+		// strings and slices of strings always marshal
+		k, _ := json.Marshal(key)
+		v, _ := json.Marshal(value(col))
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+}
+
+// documentVersion is the version of the document form written by Parser.WriteJSON
+const documentVersion = 1
+
+// Document is the full form of the tables of a document written by Parser.WriteJSON
+type Document struct {
+	Version int
+	Tables  []DocumentTable
+}
+
+// DocumentTable is a table of a Document along with everything known about it
+type DocumentTable struct {
+	// Name is the name of the table from Parser.TableNames
+	Name string
+	Rows Table
+	Meta *TableMeta
+	// Cells lists each cell of the table once, with the position and spans that place it in Rows
+	Cells []DocumentCell
+	// Diagnostics are the problems found in the table
+	Diagnostics []Diagnostic
+}
+
+// DocumentCell is a Cell of a DocumentTable
+type DocumentCell struct {
+	*Cell
+	// Scale is the scale applied by Cell.Float and Cell.Int when parsing WithAutoScale, or 0
+	Scale float64 `json:",omitempty"`
+}
+
+// Diagnostic is a problem found in a table, at its row and column, or -1 when it concerns them all
+type Diagnostic struct {
+	Row     int
+	Col     int
+	Message string
+}
+
+// WriteJSON writes every parsed table to w as a Document, which ReadJSON reads back
+func (p *Parser) WriteJSON(w io.Writer) error {
+	doc := Document{Version: documentVersion, Tables: []DocumentTable{}}
+	names := p.TableNames()
+	for i, t := range p.Tables {
+		dt := DocumentTable{Name: names[i], Rows: *t}
+		if i < len(p.Meta) {
+			dt.Meta = p.Meta[i]
+			seen := map[*Cell]bool{}
+			for _, row := range p.Meta[i].Cells {
+				for _, cell := range row {
+					if cell != nil && !seen[cell] {
+						seen[cell] = true
+						dt.Cells = append(dt.Cells, DocumentCell{Cell: cell, Scale: cell.scale})
+					}
+				}
+			}
+		}
+		dt.Diagnostics = diagnose(t, dt.Meta)
+		doc.Tables = append(doc.Tables, dt)
+	}
+	enc := json.NewEncoder(w)
+	return enc.Encode(doc)
+}
+
+// ReadJSON reads a Document written by Parser.WriteJSON, restoring the Tables and Meta of the Parser
+func ReadJSON(r io.Reader) (*Parser, error) {
+	var doc Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	if doc.Version != documentVersion {
+		return nil, fmt.Errorf("unsupported document version %d", doc.Version)
+	}
+	p := &Parser{visibility: DefaultVisibility}
+	for i, dt := range doc.Tables {
+		t := dt.Rows
+		p.Tables = append(p.Tables, &t)
+		meta := dt.Meta
+		if meta == nil {
+			meta = &TableMeta{Index: i, Unit: Unit{Scale: 1}}
+		}
+		meta.Cells = make([][]*Cell, len(t))
+		for row := range t {
+			meta.Cells[row] = make([]*Cell, len(t[row]))
+		}
+		for _, dc := range dt.Cells {
+			if dc.Cell == nil {
+				continue
+			}
+			cell := dc.Cell
+			cell.scale = dc.Scale
+			for row := cell.Row; row < cell.Row+cell.RowSpan && row < len(t); row++ {
+				for col := cell.Col; col < cell.Col+cell.ColSpan && col < len(t[row]); col++ {
+					if row >= 0 && col >= 0 {
+						meta.Cells[row][col] = cell
+					}
+				}
+			}
+		}
+		p.Meta = append(p.Meta, meta)
+	}
+	return p, nil
+}
+
+// diagnose lists the rows shorter than the table and the totals that do not add up
+func diagnose(t *Table, m *TableMeta) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	width := columnCount(*t)
+	for i, row := range *t {
+		if len(row) < width {
+			diagnostics = append(diagnostics, Diagnostic{
+				Row:     i,
+				Col:     len(row),
+				Message: fmt.Sprintf("row has %d of %d columns", len(row), width),
+			})
+		}
+	}
+	for _, c := range Unreconciled(ClassifyRows(t, m)) {
+		for _, mismatch := range c.Mismatches {
+			diagnostics = append(diagnostics, Diagnostic{
+				Row:     c.Row,
+				Col:     mismatch.Col,
+				Message: fmt.Sprintf("%s is %v but its rows sum to %v", c.Kind, mismatch.Value, mismatch.Sum),
+			})
+		}
+	}
+	if m != nil && m.Layout {
+		diagnostics = append(diagnostics, Diagnostic{Row: -1, Col: -1, Message: "table appears to be used for layout"})
+	}
+	return diagnostics
+}
@@ -0,0 +1,68 @@
+package htmltable
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	ts, err := NewFromString(testTable2)
+	assertNoError(t, err)
+	table := *ts[0]
+	table = table[:3]
+
+	var buf bytes.Buffer
+	assertNoError(t, table.WriteJSON(&buf, JSONArrays))
+	data, err := json.Marshal(table)
+	assertNoError(t, err)
+	assertEqual(t, string(data)+"\n", buf.String())
+
+	buf.Reset()
+	assertNoError(t, table.WriteJSON(&buf, JSONObjects))
+	assertEqual(t, `[{"Date":"June 21, 2022","Added Ticker":"KDP","Added Security":"Keurig Dr Pepper","Removed Ticker":"UA/UAA","Removed Security":"Under Armour","Reason":"Market capitalization change. [4]"}]`+"\n", buf.String())
+
+	buf.Reset()
+	assertNoError(t, table.WriteJSON(&buf, JSONColumns, HeaderRows(1)))
+	assertEqual(t, `{"Date":["Date","June 21, 2022"],"Added":["Ticker","KDP"],"Added 2":["Security","Keurig Dr Pepper"],`+
+		`"Removed":["Ticker","UA/UAA"],"Removed 2":["Security","Under Armour"],"Reason":["Reason","Market capitalization change. [4]"]}`+"\n", buf.String())
+
+	buf.Reset()
+	ragged := Table{{"", "b"}, {"1"}}
+	assertNoError(t, ragged.WriteJSON(&buf, JSONObjects, HeaderRows(1)))
+	assertEqual(t, `[{"0":"1","b":""}]`+"\n", buf.String())
+}
+
+func TestJSONDocument(t *testing.T) {
+	p, err := ParseString(`<table>
+		<caption>Segments (in thousands)</caption>
+		<tr><th></th><th>2023</th></tr>
+		<tr><td rowspan="2">Revenue</td><td>10</td></tr>
+		<tr><td>20</td></tr>
+		<tr><td style="font-weight:bold">Total</td><td>35</td></tr>
+		<tr><td>Note</td></tr>
+	</table>`, WithAutoScale())
+	assertNoError(t, err)
+
+	var buf bytes.Buffer
+	assertNoError(t, p.WriteJSON(&buf))
+	assertEqual(t, true, strings.Contains(buf.String(), `"Diagnostics":[{"Row":4,"Col":1,"Message":"row has 1 of 2 columns"},{"Row":3,"Col":1,"Message":"total is 35 but its rows sum to 30"}]`))
+
+	q, err := ReadJSON(&buf)
+	assertNoError(t, err)
+	assertEqual(t, p.Tables, q.Tables)
+	assertEqual(t, p.Meta[0].Caption, q.Meta[0].Caption)
+	assertEqual(t, p.Meta[0].Unit, q.Meta[0].Unit)
+	assertEqual(t, len(p.Meta[0].Cells), len(q.Meta[0].Cells))
+	revenue := q.Meta[0].Cells[2][0]
+	assertEqual(t, revenue, q.Meta[0].Cells[1][0])
+	assertEqual(t, 2, revenue.RowSpan)
+	assertEqual(t, true, q.Meta[0].Cells[3][0].Style.Bold())
+	f, err := q.Meta[0].Cells[2][1].Float()
+	assertNoError(t, err)
+	assertEqual(t, 20000.0, f)
+
+	_, err = ReadJSON(strings.NewReader(`{"Version":2}`))
+	assertEqual(t, "unsupported document version 2", err.Error())
+}
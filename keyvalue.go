@@ -0,0 +1,101 @@
+package htmltable
+
+import (
+	"strings"
+)
+
+// KeyValue is a label and its value
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// KeyValues are label/value pairs in the order they appear in a table
+type KeyValues []KeyValue
+
+// Get returns the value of the first pair with key, compared after normalizing case, punctuation and whitespace
+func (kv KeyValues) Get(key string) (string, bool) {
+	want := normalize(key)
+	for _, pair := range kv {
+		if normalize(pair.Key) == want {
+			return pair.Value, true
+		}
+	}
+	return "", false
+}
+
+// Values returns the values of every pair with key, compared as in Get
+func (kv KeyValues) Values(key string) []string {
+	want := normalize(key)
+	var values []string
+	for _, pair := range kv {
+		if normalize(pair.Key) == want {
+			values = append(values, pair.Value)
+		}
+	}
+	return values
+}
+
+// Keys returns the keys in order
+func (kv KeyValues) Keys() []string {
+	keys := make([]string, len(kv))
+	for i, pair := range kv {
+		keys[i] = pair.Key
+	}
+	return keys
+}
+
+// Map returns the pairs as a map, keeping the first value of repeated keys
+func (kv KeyValues) Map() map[string]string {
+	m := make(map[string]string, len(kv))
+	for _, pair := range kv {
+		if _, ok := m[pair.Key]; !ok {
+			m[pair.Key] = pair.Value
+		}
+	}
+	return m
+}
+
+// KeyValues reads a table of labels and values, as found on cover pages and fact sheets,
+// e.g. "Trading symbol" | "UPST".
+//
+// The non-empty cells of each row are paired in order, so that rows laying out several pairs
+// (label, value, label, value) give a pair for each. With the TableMeta given by UseMeta, a cell repeated
+// by a column span counts once. A label ending in a colon followed by another such label, or at the end of its row,
+// has an empty value. The trailing colon is removed from keys.
+func (t Table) KeyValues(opts ...LookupOption) KeyValues {
+	l := newLookup(t, opts)
+	var kv KeyValues
+	for r, row := range t {
+		var cells []string
+		for i, cell := range row {
+			if cell == "" {
+				continue
+			}
+			if l.meta != nil && r < len(l.meta.Cells) && i < len(l.meta.Cells[r]) {
+				if c := l.meta.Cells[r][i]; c != nil && c.Col != i {
+					continue
+				}
+			}
+			cells = append(cells, cell)
+		}
+		for i := 0; i < len(cells); i++ {
+			pair := KeyValue{Key: strings.TrimSpace(strings.TrimSuffix(cells[i], ":"))}
+			if i+1 < len(cells) && !(strings.HasSuffix(cells[i], ":") && strings.HasSuffix(cells[i+1], ":")) {
+				pair.Value = cells[i+1]
+				i++
+			}
+			kv = append(kv, pair)
+		}
+	}
+	return kv
+}
+
+// WithDefinitionLists makes the parser read <dl> definition lists as tables of two columns,
+// with a row for each term and its definition, so that Table.KeyValues reads them as well.
+// Terms sharing a definition, and terms with several definitions, are repeated on each of their rows.
+func WithDefinitionLists() Option {
+	return func(p *Parser) {
+		p.definitionLists = true
+	}
+}
@@ -0,0 +1,67 @@
+package htmltable
+
+import (
+	"testing"
+)
+
+func TestKeyValues(t *testing.T) {
+	p, err := ParseString(`<table>
+		<tr><td>Title of each class</td><td colspan="2">Common stock</td></tr>
+		<tr><td>Shares</td><td>100</td><td>100</td></tr>
+		<tr><td>Trading symbol:</td><td>UPST</td><td>Exchange:</td><td>NASDAQ</td></tr>
+		<tr><td>Large accelerated filer:</td><td>Accelerated filer:</td><td>☒</td></tr>
+		<tr><td></td><td></td></tr>
+	</table>`)
+	assertNoError(t, err)
+	kv := p.Tables[0].KeyValues(UseMeta(p.Meta[0]))
+	assertEqual(t, KeyValues{
+		{"Title of each class", "Common stock"},
+		{"Shares", "100"},
+		{"100", ""},
+		{"Trading symbol", "UPST"},
+		{"Exchange", "NASDAQ"},
+		{"Large accelerated filer", ""},
+		{"Accelerated filer", "☒"},
+	}, kv)
+	v, ok := kv.Get("trading symbol")
+	assertEqual(t, true, ok)
+	assertEqual(t, "UPST", v)
+	_, ok = kv.Get("CIK")
+	assertEqual(t, false, ok)
+	assertEqual(t, "NASDAQ", kv.Map()["Exchange"])
+
+	// without the meta spans are not known, so the spanned value is repeated
+	kv = p.Tables[0].KeyValues()
+	assertEqual(t, KeyValue{"Common stock", ""}, kv[1])
+}
+
+func TestDefinitionLists(t *testing.T) {
+	const doc = `<dl>
+		<dt>Exchange</dt><dd>NASDAQ</dd>
+		<dt>Ticker</dt><dt>Symbol</dt><dd>UPST</dd>
+		<dt>Auditor</dt><dd>Deloitte</dd><dd>KPMG</dd>
+		<dt>Notes</dt>
+	</dl>
+	<table><tr><td>a</td><td>b</td></tr></table>`
+	ts, err := NewFromString(doc)
+	assertNoError(t, err)
+	assertEqual(t, 1, len(ts))
+
+	p, err := ParseString(doc, WithDefinitionLists())
+	assertNoError(t, err)
+	assertEqual(t, 2, len(p.Tables))
+	assertEqual(t, 2, len(p.Meta))
+	kv := p.Tables[0].KeyValues(UseMeta(p.Meta[0]))
+	assertEqual(t, KeyValues{
+		{"Exchange", "NASDAQ"},
+		{"Ticker", "UPST"},
+		{"Symbol", "UPST"},
+		{"Auditor", "Deloitte"},
+		{"Auditor", "KPMG"},
+		{"Notes", ""},
+	}, kv)
+	assertEqual(t, []string{"Deloitte", "KPMG"}, kv.Values("auditor"))
+	assertEqual(t, true, p.Meta[0].Cells[1][0].Header)
+	assertEqual(t, 3, p.Meta[0].Cells[3][0].Row)
+	assertEqual(t, 4, p.Meta[0].Cells[4][0].Row)
+}
@@ -0,0 +1,158 @@
+package htmltable
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// WithoutLayoutTables drops tables classified as layout from the results, keeping only data tables
+func WithoutLayoutTables() Option {
+	return func(p *Parser) {
+		p.dropLayout = true
+	}
+}
+
+// checkboxes are used in the checkbox grids of SEC cover pages
+const checkboxes = "☐☑☒✓✔✗✘"
+
+// dataScore scores how likely table t, parsed from node n, is to hold data rather than lay out the page.
+// Positive scores are data tables and negative ones layout tables.
+//
+// n may be nil, in which case only the content of the table is considered.
+func (p *Parser) dataScore(t Table, meta *TableMeta, n *html.Node) float64 {
+	score := 0.0
+
+	distinct := map[string]bool{}
+	filled, numeric, long, header, checkbox, signature := 0, 0, 0, false, false, false
+	cols := 0
+	for i, row := range t {
+		if len(row) > cols {
+			cols = len(row)
+		}
+		for j, v := range row {
+			if i < len(meta.Cells) && j < len(meta.Cells[i]) && meta.Cells[i][j].Header {
+				header = true
+			}
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			filled++
+			distinct[v] = true
+			if _, ok := ParseNumber(v); ok {
+				numeric++
+			}
+			if len(v) > 150 {
+				long++
+			}
+			if strings.ContainsAny(v, checkboxes) {
+				checkbox = true
+			}
+			lower := strings.ToLower(v)
+			if strings.HasPrefix(lower, "/s/") || lower == "signature" || lower == "by:" {
+				signature = true
+			}
+		}
+	}
+
+	// tiny tables are used to position a single piece of content
+	if len(distinct) < 2 {
+		score -= 2
+	}
+	if len(t) == 1 {
+		score -= 1
+	}
+	if cols == 1 {
+		score -= 1
+	}
+	if filled > 0 {
+		density := float64(numeric) / float64(filled)
+		switch {
+		case density >= 0.3:
+			score += 2
+		case density > 0:
+			score += 1
+		}
+		// paragraphs of text are laid out rather than tabulated
+		if float64(long)/float64(filled) > 0.3 {
+			score -= 1.5
+		}
+	}
+	if len(t) >= 3 && cols >= 2 {
+		score += 1
+	}
+	if header || meta.Caption != "" {
+		score += 1.5
+	}
+	if checkbox {
+		score -= 2
+	}
+	if signature {
+		score -= 1.5
+	}
+
+	if n != nil {
+		switch strings.ToLower(strings.TrimSpace(getAttr(n, "role"))) {
+		case "presentation", "none":
+			score -= 5
+		}
+		if hasDescendantTable(n) {
+			score -= 2
+		}
+		border, _ := intAttr(n, "border")
+		if border > 0 {
+			score += 0.5
+		}
+		// padding spaces out values for reading, while zero spacing and padding place content exactly
+		spacing, hasSpacing := intAttr(n, "cellspacing")
+		padding, hasPadding := intAttr(n, "cellpadding")
+		switch {
+		case padding > 0 || spacing > 0:
+			score += 0.5
+		case hasSpacing && hasPadding && border <= 0:
+			score -= 0.5
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.Data == "thead" {
+				score += 0.5
+			}
+		}
+	}
+	return score
+}
+
+// intAttr returns the whole number value of attribute key of n, and whether it has one
+func intAttr(n *html.Node, key string) (int, bool) {
+	if !hasAttr(n, key) {
+		return 0, false
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(getAttr(n, key)))
+	return v, err == nil
+}
+
+// hasDescendantTable reports whether there is a table element below n
+func hasDescendantTable(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "table" || hasDescendantTable(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// dropLayoutTables removes layout tables from the results, keeping Tables and Meta aligned
+func (p *Parser) dropLayoutTables() {
+	var tables []*Table
+	var meta []*TableMeta
+	for i, m := range p.Meta {
+		if m.Layout {
+			continue
+		}
+		tables = append(tables, p.Tables[i])
+		meta = append(meta, m)
+	}
+	p.Tables = tables
+	p.Meta = meta
+}
@@ -0,0 +1,61 @@
+package htmltable
+
+import "testing"
+
+func TestDataScore(t *testing.T) {
+	for _, html := range []string{testTable1, testTable2, testTable3, testTableIncomeStatement} {
+		p, err := ParseString(html)
+		assertNoError(t, err)
+		for _, m := range p.Meta {
+			assertEqual(t, false, m.Layout)
+		}
+	}
+
+	p, err := ParseString(testTableLayout)
+	assertNoError(t, err)
+	var layout []bool
+	for _, m := range p.Meta {
+		layout = append(layout, m.Layout)
+	}
+	assertEqual(t, []bool{true, true, true, true, false}, layout)
+}
+
+func TestDataScoreAttributes(t *testing.T) {
+	const rows = `<tr><td>Name</td><td>Office</td></tr><tr><td>Ann Lee</td><td>Boston</td></tr>`
+	p, err := ParseString(`<table>` + rows + `</table>` +
+		`<table cellspacing="0" cellpadding="0">` + rows + `</table>` +
+		`<table cellspacing="0" cellpadding="4">` + rows + `</table>` +
+		`<table cellspacing="0" cellpadding="0" border="1">` + rows + `</table>`)
+	assertNoError(t, err)
+	plain := p.Meta[0].DataScore
+	assertEqual(t, plain-0.5, p.Meta[1].DataScore)
+	assertEqual(t, plain+0.5, p.Meta[2].DataScore)
+	assertEqual(t, plain+0.5, p.Meta[3].DataScore)
+}
+
+func TestWithoutLayoutTables(t *testing.T) {
+	p, err := ParseString(testTableLayout, WithoutLayoutTables())
+	assertNoError(t, err)
+	assertEqual(t, 1, len(p.Tables))
+	assertEqual(t, 1, len(p.Meta))
+	assertEqual(t, 4, p.Meta[0].Index)
+	assertEqual(t, "Shares outstanding", (*p.Tables[0])[0][1])
+}
+
+const testTableLayout = `<body>
+<table role="presentation"><tr><td>Logo</td><td>Menu</td></tr><tr><td>1</td><td>2</td></tr></table>
+<table cellspacing="0" cellpadding="0"><tr><td>UNITED STATES SECURITIES AND EXCHANGE COMMISSION</td></tr></table>
+<table>
+	<tr><td>☒</td><td>QUARTERLY REPORT PURSUANT TO SECTION 13 OR 15(d)</td></tr>
+	<tr><td>☐</td><td>TRANSITION REPORT PURSUANT TO SECTION 13 OR 15(d)</td></tr>
+</table>
+<table>
+	<tr><td>By:</td><td>/s/ Dave Girouard</td></tr>
+	<tr><td></td><td>Chief Executive Officer</td></tr>
+</table>
+<table>
+	<tr><th>Class</th><th>Shares outstanding</th></tr>
+	<tr><td>Common stock</td><td>85,437,110</td></tr>
+	<tr><td>Preferred stock</td><td>0</td></tr>
+</table>
+</body>`
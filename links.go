@@ -0,0 +1,156 @@
+package htmltable
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Link is an <a href> found within a cell
+type Link struct {
+	// Href is the target of the link, resolved against the base url when one is known
+	Href  string
+	Text  string
+	Title string
+}
+
+// Image is an <img> found within a cell
+type Image struct {
+	// Src is the source of the image, resolved against the base url when one is known
+	Src   string
+	Alt   string
+	Title string
+}
+
+// Annotation is a machine-readable value attached to text within a cell,
+// such as the title of an <abbr>, the datetime of a <time> or the value of a <data>
+type Annotation struct {
+	// Element and Attr name where the value came from, e.g. "time" and "datetime"
+	Element string
+	Attr    string
+	Value   string
+	// Text is the visible text the value is attached to
+	Text string
+}
+
+// annotationAttrs lists the attribute holding the machine-readable value of each element
+var annotationAttrs = map[string]string{
+	"abbr":    "title",
+	"acronym": "title",
+	"time":    "datetime",
+	"data":    "value",
+}
+
+// WithBaseURL resolves relative link and image urls against base.
+// A <base href> in the document is itself resolved against base and then used instead.
+func WithBaseURL(base *url.URL) Option {
+	return func(p *Parser) {
+		p.baseURL = base
+	}
+}
+
+// WithAltText uses the alt text or title of an image, or the value of an annotation,
+// as the value of a cell that has no visible text
+func WithAltText() Option {
+	return func(p *Parser) {
+		p.altText = true
+	}
+}
+
+// findBaseURL applies the first <base href> of the document to the parser's base url.
+// A relative href is only used when it can be resolved against the url given by WithBaseURL.
+func (p *Parser) findBaseURL(n *html.Node) bool {
+	if n.Type == html.ElementNode && n.Data == "base" && hasAttr(n, "href") {
+		href, err := url.Parse(strings.TrimSpace(getAttr(n, "href")))
+		if err != nil {
+			return true
+		}
+		if p.baseURL != nil {
+			href = p.baseURL.ResolveReference(href)
+		}
+		if href.IsAbs() {
+			p.baseURL = href
+		}
+		return true
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if p.findBaseURL(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveURL resolves ref against the base url, returning it unchanged if that is not possible
+func (p *Parser) resolveURL(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if p.baseURL == nil {
+		return ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return p.baseURL.ResolveReference(u).String()
+}
+
+// collectReferences adds the links, images and annotations below n to cell
+func (p *Parser) collectReferences(n *html.Node, cell *Cell) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || p.isHidden(c) {
+			continue
+		}
+		if _, ok := p.footnoteMarker(c); ok {
+			continue
+		}
+		switch c.Data {
+		case "a":
+			if hasAttr(c, "href") {
+				cell.Links = append(cell.Links, Link{
+					Href:  p.resolveURL(getAttr(c, "href")),
+					Text:  p.plainText(c),
+					Title: getAttr(c, "title"),
+				})
+			}
+		case "img":
+			cell.Images = append(cell.Images, Image{
+				Src:   p.resolveURL(getAttr(c, "src")),
+				Alt:   strings.TrimSpace(getAttr(c, "alt")),
+				Title: strings.TrimSpace(getAttr(c, "title")),
+			})
+		}
+		if attr, ok := annotationAttrs[c.Data]; ok && hasAttr(c, attr) {
+			cell.Annotations = append(cell.Annotations, Annotation{
+				Element: c.Data,
+				Attr:    attr,
+				Value:   strings.TrimSpace(getAttr(c, attr)),
+				Text:    p.plainText(c),
+			})
+		}
+		p.collectReferences(c, cell)
+	}
+}
+
+// altText returns the text to use for a cell with no visible text, or "" if there is none
+func (cell *Cell) altText() string {
+	for _, img := range cell.Images {
+		if img.Alt != "" {
+			return img.Alt
+		}
+		if img.Title != "" {
+			return img.Title
+		}
+	}
+	for _, a := range cell.Annotations {
+		if a.Value != "" {
+			return a.Value
+		}
+	}
+	for _, l := range cell.Links {
+		if l.Title != "" {
+			return l.Title
+		}
+	}
+	return ""
+}
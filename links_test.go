@@ -0,0 +1,65 @@
+package htmltable
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestLinks(t *testing.T) {
+	base, err := url.Parse("https://en.wikipedia.org/wiki/List_of_S%26P_500_companies")
+	assertNoError(t, err)
+	p, err := ParseString(testTable2, WithBaseURL(base), WithFootnotes())
+	assertNoError(t, err)
+	cells := p.Meta[0].Cells
+	assertEqual(t, []Link{{
+		Href:  "https://en.wikipedia.org/wiki/Keurig_Dr_Pepper",
+		Text:  "Keurig Dr Pepper",
+		Title: "Keurig Dr Pepper",
+	}}, cells[2][2].Links)
+	assertEqual(t, 0, len(cells[2][5].Links))
+
+	p, err = ParseString(testTable2)
+	assertNoError(t, err)
+	assertEqual(t, "/wiki/Under_Armour", p.Meta[0].Cells[2][4].Links[0].Href)
+	assertEqual(t, "#cite_note-sp20220603-4", p.Meta[0].Cells[2][5].Links[0].Href)
+}
+
+func TestImagesAndAnnotations(t *testing.T) {
+	p, err := ParseString(testTableReferences)
+	assertNoError(t, err)
+	assertEqual(t, *p.Tables[0], Table{
+		{"", "", "Q3"},
+	})
+	cells := p.Meta[0].Cells
+	assertEqual(t, []Image{{Src: "https://example.com/img/check.png", Alt: "Yes", Title: "checked"}}, cells[0][0].Images)
+	assertEqual(t, []Annotation{{Element: "time", Attr: "datetime", Value: "2023-09-30"}}, cells[0][1].Annotations)
+	assertEqual(t, []Annotation{{Element: "abbr", Attr: "title", Value: "Third quarter", Text: "Q3"}}, cells[0][2].Annotations)
+
+	ts, err := NewFromString(testTableReferences, WithAltText())
+	assertNoError(t, err)
+	assertEqual(t, *ts[0], Table{
+		{"Yes", "2023-09-30", "Q3"},
+	})
+}
+
+func TestRelativeBaseURL(t *testing.T) {
+	const html = `<head><base href="/filings/"></head><table><tr><td><a href="10-k.htm">10-K</a></td></tr></table>`
+	p, err := ParseString(html)
+	assertNoError(t, err)
+	assertEqual(t, "10-k.htm", p.Meta[0].Cells[0][0].Links[0].Href)
+
+	base, err := url.Parse("https://example.com/archives/")
+	assertNoError(t, err)
+	p, err = ParseString(html, WithBaseURL(base))
+	assertNoError(t, err)
+	assertEqual(t, "https://example.com/filings/10-k.htm", p.Meta[0].Cells[0][0].Links[0].Href)
+}
+
+const testTableReferences = `<head><base href="https://example.com/filings/"></head>
+<table>
+	<tr>
+		<td><img src="/img/check.png" alt="Yes" title="checked"></td>
+		<td><time datetime="2023-09-30"></time></td>
+		<td><abbr title="Third quarter">Q3</abbr></td>
+	</tr>
+</table>`
@@ -0,0 +1,331 @@
+package htmltable
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrNotFound is returned by lookups when nothing matches
+	ErrNotFound = errors.New("not found")
+	// ErrAmbiguous is returned by lookups when several different columns or rows match
+	ErrAmbiguous = errors.New("ambiguous")
+)
+
+// LookupOption configures the lookup methods of Table
+type LookupOption func(*lookup)
+
+// lookup holds the settings of a single lookup
+type lookup struct {
+	headerRows  int // -1 to guess
+	labelColumn int
+	match       func(want, have string) bool
+	meta        *TableMeta
+}
+
+// HeaderRows sets the number of rows at the top of the table holding the column headers.
+// By default these are the rows before the first row with a number after its label or,
+// in tables without numbers, the first row and any rows below its merged cells.
+func HeaderRows(n int) LookupOption {
+	return func(l *lookup) {
+		l.headerRows = n
+	}
+}
+
+// LabelColumn sets the column holding the row labels, which is the first column by default
+func LabelColumn(col int) LookupOption {
+	return func(l *lookup) {
+		l.labelColumn = col
+	}
+}
+
+// UseMeta provides the TableMeta of the table, for the indentation of its row labels and its spans
+func UseMeta(m *TableMeta) LookupOption {
+	return func(l *lookup) {
+		l.meta = m
+	}
+}
+
+// MatchExact requires names to equal a header or label exactly, apart from surrounding whitespace.
+// By default names are compared after normalizing case, punctuation and whitespace.
+func MatchExact() LookupOption {
+	return func(l *lookup) {
+		l.match = func(want, have string) bool {
+			return strings.TrimSpace(want) == strings.TrimSpace(have)
+		}
+	}
+}
+
+// MatchFuzzy lets names match headers and labels that contain their words or differ by a few characters,
+// after normalizing case, punctuation and whitespace
+func MatchFuzzy() LookupOption {
+	return func(l *lookup) {
+		l.match = fuzzyMatch
+	}
+}
+
+func newLookup(t Table, opts []LookupOption) *lookup {
+	l := &lookup{
+		headerRows: -1,
+		match:      normalizedMatch,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.headerRows < 0 {
+		l.headerRows = lookupHeaderRows(t)
+	}
+	if l.headerRows > len(t) {
+		l.headerRows = len(t)
+	}
+	return l
+}
+
+// lookupHeaderRows guesses the header rows for lookups and for writing headers, as headerRowCount does,
+// except that tables without numbers have a single header row followed by any rows beneath merged header cells
+// rather than being all header.
+func lookupHeaderRows(t Table) int {
+	n := headerRowCount(t)
+	if n < len(t) {
+		return n
+	}
+	n = 1
+	for n < len(t) && hasRepeats(t[n-1]) {
+		n++
+	}
+	if n > len(t) {
+		return len(t)
+	}
+	return n
+}
+
+// hasRepeats reports whether the row has the same value in adjacent columns, as produced by a colspan
+func hasRepeats(row []string) bool {
+	for i := 1; i < len(row); i++ {
+		if strings.TrimSpace(row[i]) != "" && row[i] == row[i-1] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize lowercases s and reduces punctuation and whitespace to single spaces
+func normalize(s string) string {
+	var sb strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && sb.Len() > 0 {
+				sb.WriteRune(' ')
+			}
+			space = false
+			sb.WriteRune(r)
+			continue
+		}
+		space = true
+	}
+	return sb.String()
+}
+
+func normalizedMatch(want, have string) bool {
+	return normalize(want) == normalize(have)
+}
+
+func fuzzyMatch(want, have string) bool {
+	w, h := normalize(want), normalize(have)
+	if w == "" || h == "" {
+		return w == h
+	}
+	// whole words only, so "realized" does not match "unrealized"
+	if strings.Contains(" "+h+" ", " "+w+" ") {
+		return true
+	}
+	// allow one edit for every five characters
+	return levenshtein(w, h) <= len([]rune(w))/5
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// HeaderPaths returns, for each column, the distinct header texts from the top header row down.
+// Values repeated by a rowspan appear once.
+func (t Table) HeaderPaths(opts ...LookupOption) [][]string {
+	l := newLookup(t, opts)
+	paths := make([][]string, columnCount(t))
+	for _, row := range t[:l.headerRows] {
+		for col, v := range row {
+			v = strings.TrimSpace(v)
+			path := paths[col]
+			if v == "" || len(path) > 0 && path[len(path)-1] == v {
+				continue
+			}
+			paths[col] = append(path, v)
+		}
+	}
+	return paths
+}
+
+// Header returns the name of each column, joining multi-row headers with a space,
+// e.g. "Added Ticker" for a "Ticker" column under "Added"
+func (t Table) Header(opts ...LookupOption) []string {
+	paths := t.HeaderPaths(opts...)
+	header := make([]string, len(paths))
+	for i, path := range paths {
+		header[i] = strings.Join(path, " ")
+	}
+	return header
+}
+
+// ColumnIndex returns the index of the column named name.
+//
+// A name matches a column when it matches the whole header or any one of its header rows.
+// Columns with the same header, as produced by a colspan, count as one: the one with the most numbers,
+// then the most values, is used.
+func (t Table) ColumnIndex(name string, opts ...LookupOption) (int, error) {
+	l := newLookup(t, opts)
+	paths := t.HeaderPaths(opts...)
+	best := map[string]int{}
+	var order []string
+	for col, path := range paths {
+		header := strings.Join(path, " ")
+		matched := l.match(name, header)
+		for _, part := range path {
+			matched = matched || l.match(name, part)
+		}
+		if !matched {
+			continue
+		}
+		prev, seen := best[header]
+		if !seen {
+			order = append(order, header)
+			best[header] = col
+		} else if t.columnValues(col, l) > t.columnValues(prev, l) {
+			best[header] = col
+		}
+	}
+	switch len(order) {
+	case 0:
+		return 0, fmt.Errorf("column %q: %w", name, ErrNotFound)
+	case 1:
+		return best[order[0]], nil
+	}
+	return 0, fmt.Errorf("column %q matches %q: %w", name, order, ErrAmbiguous)
+}
+
+// columnValues scores a column by its values below the header, with numbers counting double
+func (t Table) columnValues(col int, l *lookup) int {
+	score := 0
+	for _, row := range t[l.headerRows:] {
+		if col >= len(row) || strings.TrimSpace(row[col]) == "" {
+			continue
+		}
+		score++
+		if _, ok := ParseNumber(row[col]); ok {
+			score++
+		}
+	}
+	return score
+}
+
+// Column returns the values below the header of the column named name, as found by ColumnIndex
+func (t Table) Column(name string, opts ...LookupOption) ([]string, error) {
+	col, err := t.ColumnIndex(name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	l := newLookup(t, opts)
+	values := make([]string, 0, len(t)-l.headerRows)
+	for _, row := range t[l.headerRows:] {
+		v := ""
+		if col < len(row) {
+			v = row[col]
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// RowIndex returns the index of the row below the header labelled label.
+// Identical rows, as produced by a rowspan, count as one.
+func (t Table) RowIndex(label string, opts ...LookupOption) (int, error) {
+	l := newLookup(t, opts)
+	found := -1
+	var labels []string
+	for i := l.headerRows; i < len(t); i++ {
+		row := t[i]
+		if l.labelColumn >= len(row) || !l.match(label, row[l.labelColumn]) {
+			continue
+		}
+		if found < 0 {
+			found = i
+			labels = append(labels, row[l.labelColumn])
+			continue
+		}
+		if !sameRow(t[found], row) {
+			labels = append(labels, row[l.labelColumn])
+		}
+	}
+	switch {
+	case found < 0:
+		return 0, fmt.Errorf("row %q: %w", label, ErrNotFound)
+	case len(labels) > 1:
+		return 0, fmt.Errorf("row %q matches %q: %w", label, labels, ErrAmbiguous)
+	}
+	return found, nil
+}
+
+// Row returns the values of the row labelled label, as found by RowIndex
+func (t Table) Row(label string, opts ...LookupOption) ([]string, error) {
+	i, err := t.RowIndex(label, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return t[i], nil
+}
+
+// Cell returns the value at the row labelled rowLabel and the column named colName
+func (t Table) Cell(rowLabel, colName string, opts ...LookupOption) (string, error) {
+	row, err := t.RowIndex(rowLabel, opts...)
+	if err != nil {
+		return "", err
+	}
+	col, err := t.ColumnIndex(colName, opts...)
+	if err != nil {
+		return "", err
+	}
+	if col >= len(t[row]) {
+		return "", nil
+	}
+	return t[row][col], nil
+}
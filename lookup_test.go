@@ -0,0 +1,89 @@
+package htmltable
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHeader(t *testing.T) {
+	ts, err := NewFromString(testTable2)
+	assertNoError(t, err)
+	table := *ts[0]
+	assertEqual(t, []string{"Date", "Added Ticker", "Added Security", "Removed Ticker", "Removed Security", "Reason"}, table.Header())
+	assertEqual(t, []string{"Added", "Ticker"}, table.HeaderPaths()[1])
+	assertEqual(t, []string{"Date", "Added", "Added", "Removed", "Removed", "Reason"}, table.Header(HeaderRows(1)))
+}
+
+func TestColumnLookup(t *testing.T) {
+	ts, err := NewFromString(testTable2)
+	assertNoError(t, err)
+	table := *ts[0]
+
+	col, err := table.Column("added ticker")
+	assertNoError(t, err)
+	assertEqual(t, []string{"KDP", "ON"}, col)
+
+	_, err = table.Column("Ticker")
+	assertEqual(t, true, errors.Is(err, ErrAmbiguous))
+	assertEqual(t, `column "Ticker" matches ["Added Ticker" "Removed Ticker"]: ambiguous`, err.Error())
+
+	_, err = table.Column("Price")
+	assertEqual(t, true, errors.Is(err, ErrNotFound))
+
+	_, err = table.Column("reason", MatchExact())
+	assertEqual(t, true, errors.Is(err, ErrNotFound))
+	i, err := table.ColumnIndex("Reasons", MatchFuzzy())
+	assertNoError(t, err)
+	assertEqual(t, 5, i)
+}
+
+func TestRowAndCellLookup(t *testing.T) {
+	ts, err := NewFromString(testTable3)
+	assertNoError(t, err)
+	table := *ts[0]
+
+	v, err := table.Cell("Interest expense (1)", "Three Months Ended September 30, 2023")
+	assertNoError(t, err)
+	assertEqual(t, "( 9,414 )", v)
+	v, err = table.Cell("interest income 1", "Nine Months Ended September 30 2022")
+	assertNoError(t, err)
+	assertEqual(t, "66,288", v)
+
+	_, err = table.Cell("Interest income (1)", "2023")
+	assertEqual(t, true, errors.Is(err, ErrAmbiguous))
+
+	row, err := table.Row("Realized loss", MatchFuzzy())
+	assertNoError(t, err)
+	assertEqual(t, "( 2,955 )", row[9])
+
+	_, err = table.Row("Total", MatchFuzzy())
+	assertEqual(t, true, errors.Is(err, ErrAmbiguous))
+	_, err = table.RowIndex("Revenue")
+	assertEqualError(t, err, `row "Revenue": not found`)
+}
+
+func TestLevenshtein(t *testing.T) {
+	assertEqual(t, 0, levenshtein("abc", "abc"))
+	assertEqual(t, 3, levenshtein("", "abc"))
+	assertEqual(t, 1, levenshtein("reason", "reasons"))
+	assertEqual(t, 3, levenshtein("kitten", "sitting"))
+}
+
+func TestLookupHeaderRows(t *testing.T) {
+	p, err := ParseString(`<table>
+		<tr><th rowspan="2">Name</th><th colspan="2">Contact</th></tr>
+		<tr><th>Email</th><th>Phone</th></tr>
+		<tr><td>Ann</td><td>ann@example.com</td><td>n/a</td></tr>
+		<tr><td>Bob</td><td>bob@example.com</td><td>n/a</td></tr>
+	</table>`)
+	assertNoError(t, err)
+	table := *p.Tables[0]
+	// tables of text are all header for classification, units and stitching, but not for lookups
+	assertEqual(t, len(table), headerRowCount(table))
+	assertEqual(t, 2, lookupHeaderRows(table))
+
+	p, err = ParseString(testTableIncomeStatement)
+	assertNoError(t, err)
+	assertEqual(t, 1, headerRowCount(*p.Tables[0]))
+	assertEqual(t, 1, lookupHeaderRows(*p.Tables[0]))
+}
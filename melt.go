@@ -0,0 +1,75 @@
+package htmltable
+
+import "strings"
+
+// Record is a single value of a table along with the labels that identify it
+type Record struct {
+	// RowPath is the label of the row, preceded by the labels of the rows it is nested under,
+	// e.g. ["Operating expenses", "Research and development"]
+	RowPath []string
+	// ColPath is the header of the column from the top header row down, e.g. ["Three Months Ended September 30,", "2023"]
+	ColPath []string
+	Value   string
+	// Row and Col are the position of the value in the Table
+	Row int
+	Col int
+}
+
+// Melt unpivots the table into one Record for each value below the header rows and outside the label column,
+// in table order, skipping blank values.
+//
+// Header rows are guessed unless given by HeaderRows, and labels are read from the first column unless
+// given by LabelColumn. The hierarchy of row labels is that of NewRowTree, built from the labels below the header,
+// which takes their indentation from the TableMeta given by UseMeta. With UseMeta, values repeated
+// by a rowspan or colspan are only recorded at the top left of their cell.
+func (t Table) Melt(opts ...LookupOption) []Record {
+	l := newLookup(t, opts)
+	colPaths := t.HeaderPaths(opts...)
+	body := t[l.headerRows:]
+
+	// the tree reads labels from the first column, so give it just the label column
+	labels := make(Table, len(body))
+	var labelMeta *TableMeta
+	if l.meta != nil {
+		labelMeta = &TableMeta{Cells: make([][]*Cell, len(body))}
+	}
+	for i, row := range body {
+		if l.labelColumn >= len(row) {
+			continue
+		}
+		labels[i] = []string{row[l.labelColumn]}
+		r := l.headerRows + i
+		if labelMeta != nil && r < len(l.meta.Cells) && l.labelColumn < len(l.meta.Cells[r]) {
+			labelMeta.Cells[i] = []*Cell{l.meta.Cells[r][l.labelColumn]}
+		}
+	}
+	tree := NewRowTree(&labels, labelMeta)
+
+	var records []Record
+	for i, row := range body {
+		r := l.headerRows + i
+		var rowPath []string
+		if node := tree.Nodes[i]; node != nil {
+			rowPath = node.Path()
+		}
+		for col, value := range row {
+			value = strings.TrimSpace(value)
+			if col == l.labelColumn || value == "" {
+				continue
+			}
+			if l.meta != nil && r < len(l.meta.Cells) && col < len(l.meta.Cells[r]) {
+				if cell := l.meta.Cells[r][col]; cell != nil && (cell.Row != r || cell.Col != col) {
+					continue
+				}
+			}
+			records = append(records, Record{
+				RowPath: rowPath,
+				ColPath: colPaths[col],
+				Value:   value,
+				Row:     r,
+				Col:     col,
+			})
+		}
+	}
+	return records
+}
@@ -0,0 +1,61 @@
+package htmltable
+
+import (
+	"testing"
+)
+
+func TestMelt(t *testing.T) {
+	p, err := ParseString(`<table>
+		<tr><td></td><td colspan="2">Three Months Ended September 30,</td></tr>
+		<tr><td></td><td>2023</td><td>2022</td></tr>
+		<tr><td>Revenue</td><td>1,200</td><td>1,100</td></tr>
+		<tr><td>Operating expenses:</td><td></td><td></td></tr>
+		<tr><td style="padding-left:10pt">Research and development</td><td>300</td><td>250</td></tr>
+		<tr><td style="padding-left:10pt">Sales</td><td colspan="2">200</td></tr>
+		<tr><td>Total operating expenses</td><td>500</td><td>450</td></tr>
+	</table>`)
+	assertNoError(t, err)
+	table := p.Tables[0]
+
+	records := table.Melt(UseMeta(p.Meta[0]))
+	assertEqual(t, 7, len(records))
+	assertEqual(t, Record{
+		RowPath: []string{"Revenue"},
+		ColPath: []string{"Three Months Ended September 30,", "2023"},
+		Value:   "1,200",
+		Row:     2,
+		Col:     1,
+	}, records[0])
+	assertEqual(t, []string{"Operating expenses", "Research and development"}, records[2].RowPath)
+	assertEqual(t, []string{"Three Months Ended September 30,", "2022"}, records[3].ColPath)
+	// the value spanning both years is recorded once
+	assertEqual(t, "200", records[4].Value)
+	assertEqual(t, "500", records[5].Value)
+	assertEqual(t, []string{"Operating expenses", "Total operating expenses"}, records[5].RowPath)
+
+	// without the meta the spanned value is repeated and only colon sections nest
+	records = table.Melt()
+	assertEqual(t, 8, len(records))
+	assertEqual(t, []string{"Operating expenses", "Sales"}, records[5].RowPath)
+
+	records = table.Melt(LabelColumn(2), HeaderRows(2))
+	assertEqual(t, 9, len(records))
+	assertEqual(t, Record{RowPath: []string{"1,100"}, Value: "Revenue", Row: 2, Col: 0}, records[0])
+
+	// labels in another column nest as in the first
+	p, err = ParseString(`<table>
+		<tr><td>Code</td><td>Item</td><td>2023</td></tr>
+		<tr><td>100</td><td>Operating expenses:</td><td></td></tr>
+		<tr><td>110</td><td style="padding-left:10pt">Research and development</td><td>300</td></tr>
+	</table>`)
+	assertNoError(t, err)
+	records = p.Tables[0].Melt(LabelColumn(1), HeaderRows(1), UseMeta(p.Meta[0]))
+	assertEqual(t, Record{
+		RowPath: []string{"Operating expenses", "Research and development"},
+		ColPath: []string{"2023"},
+		Value:   "300",
+		Row:     2,
+		Col:     2,
+	}, records[2])
+	assertEqual(t, []string{"Operating expenses"}, records[0].RowPath)
+}
@@ -0,0 +1,63 @@
+package htmltable
+
+// TableMeta holds information about a parsed Table beyond its string data
+type TableMeta struct {
+	// Index is the position of the table among all tables parsed from the document
+	Index int
+	// Parts lists the Index of each table merged into this one when parsing WithStitching,
+	// and is empty for tables that were not merged
+	Parts []int
+	// Hidden is set when the table, or one of its ancestors, is hidden.
+	// Hidden tables are only kept when parsing WithHiddenIncluded.
+	Hidden bool
+	// HiddenRows lists the indexes of hidden rows within the table.
+	// Hidden rows are only kept when parsing WithHiddenIncluded.
+	HiddenRows []int
+	// Caption is the text of the <caption> of the table
+	Caption string
+	// Heading is the text of the nearest heading element (h1 to h6) before the table
+	Heading string
+	// Preceding is the text of the last few paragraphs or other blocks before the table, closest last
+	Preceding string
+	// Unit is the unit and scale declared for the amounts in the table
+	Unit Unit
+	// DataScore rates how likely the table is to hold data, positive, rather than lay out the page, negative.
+	// It weighs the number of cells, the share of numbers, header cells, nested tables,
+	// role="presentation", borders and the checkboxes and signatures of SEC cover pages.
+	DataScore float64
+	// Layout is set when the DataScore is negative.
+	// Layout tables are dropped when parsing WithoutLayoutTables.
+	Layout bool
+	// Cells holds the cell each value of the Table was taken from, at the same position.
+	// Cells spanning several rows or columns appear at each position they cover.
+	// Encoded documents list each cell once instead, see Parser.WriteJSON.
+	Cells [][]*Cell `json:"-"`
+
+	// pageBreak is set when a page break is styled between the table and the one before it
+	pageBreak bool
+}
+
+// Cell is a single <td> or <th> of a parsed table, with its value and everything else known about it
+type Cell struct {
+	// Value is the text of the cell, as found in the Table
+	Value string
+	// RowSpan and ColSpan are the number of rows and columns the cell covers
+	RowSpan int
+	ColSpan int
+	// Row and Col are the position of the top left corner of the cell within the Table
+	Row int
+	Col int
+	// Header is set for <th> cells, and those with role="columnheader" or "rowheader"
+	Header bool
+	// Footnotes are the reference markers separated from Value, when parsing WithFootnotes
+	Footnotes []Footnote
+	// Links, Images and Annotations are the references embedded in the cell, in document order
+	Links       []Link
+	Images      []Image
+	Annotations []Annotation
+	// Style is the formatting of the cell, resolved from the styles of the cell and the elements around its text
+	Style CellStyle
+
+	// scale is applied by Float and Int, when parsing WithAutoScale
+	scale float64
+}
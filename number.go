@@ -0,0 +1,229 @@
+package htmltable
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseNumber parses a number as commonly written in financial tables, e.g. "$ 1,234.5", "(3,050)",
+// "( 3,050 )" or "12.5%". Parentheses and minus signs make the number negative,
+// and a lone dash, which tables use for nil amounts, is zero.
+//
+// Currency symbols and percent signs are dropped without changing the value,
+// and commas are only accepted between groups of three digits, so "1,5" is not a number.
+func ParseNumber(s string) (float64, bool) {
+	clean, ok := cleanNumber(s)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(clean, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// fractionNumber parses s as ParseNumber does, but gives percentages as fractions, e.g. 0.125 for "12.5%".
+// Percentages are exported and unmarshalled this way, so that they keep their meaning without the percent sign.
+func fractionNumber(s string) (float64, bool) {
+	clean, ok := fractionText(s)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(clean, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// fractionText is cleanNumber giving percentages as fractions, by moving the decimal point two places left
+func fractionText(s string) (string, bool) {
+	clean, ok := cleanNumber(s)
+	if !ok || !strings.Contains(s, "%") {
+		return clean, ok
+	}
+	sign := ""
+	if strings.HasPrefix(clean, "-") {
+		sign, clean = "-", clean[1:]
+	}
+	whole, frac := clean, ""
+	if i := strings.IndexByte(clean, '.'); i >= 0 {
+		whole, frac = clean[:i], clean[i+1:]
+	}
+	whole = strings.Repeat("0", 3) + whole
+	whole, frac = strings.TrimLeft(whole[:len(whole)-2], "0"), whole[len(whole)-2:]+frac
+	if whole == "" {
+		whole = "0"
+	}
+	return sign + whole + "." + frac, true
+}
+
+// numberDecimals returns the number of digits after the decimal point of a number accepted by ParseNumber
+func numberDecimals(s string) int {
+	clean, ok := cleanNumber(s)
+	if !ok {
+		return 0
+	}
+	_, frac, found := strings.Cut(clean, ".")
+	if !found {
+		return 0
+	}
+	return len(frac)
+}
+
+// numberDashes are written in place of zero or nil amounts
+var numberDashes = map[string]bool{"-": true, "\u2014": true, "\u2013": true, "\u2212": true, "--": true}
+
+// numberPlaceholders are written in place of values that are not available or not meaningful
+var numberPlaceholders = map[string]bool{"n/a": true, "na": true, "n.a.": true, "nm": true, "n.m.": true}
+
+// isPlaceholder reports whether s stands in for a missing value, e.g. a dash or "n/a"
+func isPlaceholder(s string) bool {
+	s = strings.TrimSpace(s)
+	return numberDashes[s] || numberPlaceholders[strings.ToLower(s)]
+}
+
+// numberNoise is dropped from numbers before parsing
+var numberNoise = strings.NewReplacer(
+	",", "", " ", "", "\u00a0", "", "$", "", "\u20ac", "", "\u00a3", "", "\u00a5", "", "%", "",
+)
+
+// cleanNumber reduces a number accepted by ParseNumber to the form expected by strconv.ParseFloat
+func cleanNumber(s string) (string, bool) {
+	if strings.Contains(s, ",") && !groupsThousands(s) {
+		return "", false
+	}
+	s = numberNoise.Replace(strings.TrimSpace(s))
+	if numberDashes[s] {
+		return "0", true
+	}
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	for _, minus := range []string{"-", "\u2212", "\u2013"} {
+		if strings.HasPrefix(s, minus) {
+			if negative {
+				return "", false
+			}
+			negative = true
+			s = s[len(minus):]
+			break
+		}
+	}
+	digits, points := 0, 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '.':
+			points++
+		default:
+			return "", false
+		}
+	}
+	if digits == 0 || points > 1 {
+		return "", false
+	}
+	if negative {
+		return "-" + s, true
+	}
+	return s, true
+}
+
+// groupsThousands reports whether the commas of a number only separate groups of three digits before its
+// decimal point, so that "1,234.5" is read as a number but not "1,5"
+func groupsThousands(s string) bool {
+	var sb strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' || r == ',' || r == '.' {
+			sb.WriteRune(r)
+		}
+	}
+	whole := sb.String()
+	if i := strings.IndexByte(whole, '.'); i >= 0 {
+		if strings.Contains(whole[i:], ",") {
+			return false
+		}
+		whole = whole[:i]
+	}
+	groups := strings.Split(whole, ",")
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// Float returns the value at row and col parsed with ParseNumber, as written.
+//
+// Unlike Cell.Float it never applies the scale of WithAutoScale, as a Table holds no metadata:
+// use the Cell from TableMeta.Cells, or TableMeta.Unit.Apply, for the scaled value.
+func (t Table) Float(row, col int) (float64, error) {
+	s, err := t.value(row, col)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := ParseNumber(s)
+	if !ok {
+		return 0, fmt.Errorf("row %d col %d: %q is not a number", row, col, s)
+	}
+	return f, nil
+}
+
+// Int returns the value at row and col parsed with ParseNumber, failing if it is not a whole number.
+// Like Table.Float, it does not apply the scale of WithAutoScale.
+func (t Table) Int(row, col int) (int64, error) {
+	f, err := t.Float(row, col)
+	if err != nil {
+		return 0, err
+	}
+	return toInt(f, row, col)
+}
+
+// value returns the string at row and col, or an error if the table has no such cell
+func (t Table) value(row, col int) (string, error) {
+	if row < 0 || row >= len(t) || col < 0 || col >= len(t[row]) {
+		return "", fmt.Errorf("row %d col %d: out of range", row, col)
+	}
+	return t[row][col], nil
+}
+
+// Float returns the value of the cell parsed with ParseNumber,
+// multiplied by the scale of its table when parsing WithAutoScale
+func (c *Cell) Float() (float64, error) {
+	f, ok := ParseNumber(c.Value)
+	if !ok {
+		return 0, fmt.Errorf("row %d col %d: %q is not a number", c.Row, c.Col, c.Value)
+	}
+	if c.scale != 0 {
+		f *= c.scale
+	}
+	return f, nil
+}
+
+// Int returns the value of the cell as Float does, failing if it is not a whole number
+func (c *Cell) Int() (int64, error) {
+	f, err := c.Float()
+	if err != nil {
+		return 0, err
+	}
+	return toInt(f, c.Row, c.Col)
+}
+
+// toInt converts f to an integer if it is a whole number within range
+func toInt(f float64, row, col int) (int64, error) {
+	// float64(math.MaxInt64) rounds up to 1<<63, which is out of range
+	if f != math.Trunc(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0, fmt.Errorf("row %d col %d: %v is not an integer", row, col, f)
+	}
+	return int64(f), nil
+}
@@ -0,0 +1,80 @@
+package htmltable
+
+import "testing"
+
+func TestParseNumber(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"22,180", 22180, true},
+		{"( 3,050 )", -3050, true},
+		{"$ (22,115)", -22115, true},
+		{"$1,234.50", 1234.5, true},
+		{"-0.12", -0.12, true},
+		{"−7", -7, true},
+		{"12.5%", 12.5, true},
+		{"—", 0, true},
+		{"-", 0, true},
+		{"$", 0, false},
+		{"", 0, false},
+		{"2022", 2022, true},
+		{"Three Months Ended", 0, false},
+		{"1.2.3", 0, false},
+		{"(-5)", 0, false},
+		{"1,234,567.5", 1234567.5, true},
+		{"1,5", 0, false},
+		{"12,34", 0, false},
+		{"1234,567", 0, false},
+		{",123", 0, false},
+		{"1.234,5", 0, false},
+	} {
+		got, ok := ParseNumber(tc.in)
+		assertEqual(t, tc.want, got)
+		assertEqual(t, tc.ok, ok)
+	}
+}
+
+func TestNumberDecimals(t *testing.T) {
+	assertEqual(t, 0, numberDecimals("( 3,050 )"))
+	assertEqual(t, 2, numberDecimals("$0.12"))
+	assertEqual(t, 0, numberDecimals("n/a"))
+}
+
+func TestTableAccessors(t *testing.T) {
+	ts, err := NewFromString(testTable3)
+	assertNoError(t, err)
+	table := *ts[0]
+	f, err := table.Float(4, 3)
+	assertNoError(t, err)
+	assertEqual(t, -3050.0, f)
+	i, err := table.Int(3, 4)
+	assertNoError(t, err)
+	assertEqual(t, int64(22180), i)
+	_, err = table.Float(3, 3)
+	assertEqualError(t, err, `row 3 col 3: "$" is not a number`)
+	_, err = table.Int(100, 0)
+	assertEqualError(t, err, "row 100 col 0: out of range")
+
+	ts, err = NewFromString(`<table><tr><td>0.5</td></tr></table>`)
+	assertNoError(t, err)
+	_, err = ts[0].Int(0, 0)
+	assertEqualError(t, err, "row 0 col 0: 0.5 is not an integer")
+
+	// 1<<63 is one past the largest int64
+	_, err = toInt(1<<63, 0, 0)
+	assertEqual(t, true, err != nil)
+	_, err = toInt(-1<<63, 0, 0)
+	assertNoError(t, err)
+}
+
+func TestFractionText(t *testing.T) {
+	for in, want := range map[string]string{
+		"12.5%": "0.125", "(2.5%)": "-0.025", "1,250%": "12.50", "0.5%": "0.005", "7": "7",
+	} {
+		got, ok := fractionText(in)
+		assertEqual(t, true, ok)
+		assertEqual(t, want, got)
+	}
+}
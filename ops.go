@@ -0,0 +1,244 @@
+package htmltable
+
+// Copy returns a copy of the table.
+//
+// Copy and the other operations on a Table return new tables, leaving the table they are called on unchanged.
+// Tables may be ragged, with rows shorter than others where the html had fewer cells.
+func (t Table) Copy() Table {
+	return t.Map(func(row, col int, value string) string {
+		return value
+	})
+}
+
+// Transpose swaps the rows and columns of the table, so that row i of the result holds column i.
+// Ragged rows are padded with empty values.
+func (t Table) Transpose() Table {
+	width := columnCount(t)
+	out := make(Table, width)
+	for col := range out {
+		out[col] = make([]string, len(t))
+		for r, row := range t {
+			if col < len(row) {
+				out[col][r] = row[col]
+			}
+		}
+	}
+	return out
+}
+
+// Slice returns the rows from fromRow up to but excluding toRow, cut to the columns from fromCol
+// up to but excluding toCol. Bounds are clamped to the table, and rows shorter than fromCol become empty.
+func (t Table) Slice(fromRow, toRow, fromCol, toCol int) Table {
+	fromRow, toRow = clampRange(fromRow, toRow, len(t))
+	out := make(Table, 0, toRow-fromRow)
+	for _, row := range t[fromRow:toRow] {
+		from, to := clampRange(fromCol, toCol, len(row))
+		out = append(out, append([]string{}, row[from:to]...))
+	}
+	return out
+}
+
+// clampRange clamps from and to within 0 and n, keeping from no greater than to
+func clampRange(from, to, n int) (int, int) {
+	if from < 0 {
+		from = 0
+	}
+	if to > n {
+		to = n
+	}
+	if from > to {
+		from = to
+	}
+	return from, to
+}
+
+// Filter returns the rows for which keep returns true
+func (t Table) Filter(keep func(row []string) bool) Table {
+	out := Table{}
+	for _, row := range t {
+		if keep(row) {
+			out = append(out, append([]string{}, row...))
+		}
+	}
+	return out
+}
+
+// Select returns the columns cols, in the order given. Columns may be repeated,
+// and columns missing from ragged rows are empty.
+func (t Table) Select(cols ...int) Table {
+	out := make(Table, len(t))
+	for r, row := range t {
+		out[r] = make([]string, len(cols))
+		for i, col := range cols {
+			if col >= 0 && col < len(row) {
+				out[r][i] = row[col]
+			}
+		}
+	}
+	return out
+}
+
+// DropColumns returns the table without the columns cols
+func (t Table) DropColumns(cols ...int) Table {
+	drop := map[int]bool{}
+	for _, col := range cols {
+		drop[col] = true
+	}
+	out := make(Table, len(t))
+	for r, row := range t {
+		out[r] = []string{}
+		for col, value := range row {
+			if !drop[col] {
+				out[r] = append(out[r], value)
+			}
+		}
+	}
+	return out
+}
+
+// Map returns the table with each value replaced by the result of f
+func (t Table) Map(f func(row, col int, value string) string) Table {
+	out := make(Table, len(t))
+	for r, row := range t {
+		out[r] = make([]string, len(row))
+		for col, value := range row {
+			out[r][col] = f(r, col, value)
+		}
+	}
+	return out
+}
+
+// Concat appends the rows of other below the table, matching its columns to those of the table by their header.
+// The header rows of other are dropped, unless the table has none, and its columns with a header not found
+// in the table are added after the others, with their header in the last header row. Blank headers,
+// such as that of a label column, match in order. Header rows are guessed for each table
+// unless given by HeaderRows, which then applies to both, and headers are compared after normalizing case, punctuation and whitespace.
+func (t Table) Concat(other Table, opts ...LookupOption) Table {
+	l := newLookup(t, opts)
+	header := t.Header(opts...)
+	width := columnCount(t)
+	ol := newLookup(other, opts)
+	otherHeader := other.Header(opts...)
+
+	// position of each column of other in the result, matching repeated headers in order
+	cols := make([]int, len(otherHeader))
+	taken := make([]bool, len(header))
+	var added []string
+	for i, name := range otherHeader {
+		cols[i] = -1
+		for col, have := range header {
+			if !taken[col] && normalize(name) == normalize(have) {
+				cols[i] = col
+				taken[col] = true
+				break
+			}
+		}
+		if cols[i] < 0 {
+			cols[i] = width + len(added)
+			added = append(added, name)
+		}
+	}
+
+	out := t.Copy()
+	if len(added) > 0 {
+		for r := range out {
+			if len(out[r]) < width {
+				out[r] = append(out[r], make([]string, width-len(out[r]))...)
+			}
+			out[r] = append(out[r], make([]string, len(added))...)
+		}
+		if l.headerRows > 0 {
+			copy(out[l.headerRows-1][width:], added)
+		}
+	}
+	moved := make(Table, len(other))
+	for r, row := range other {
+		moved[r] = make([]string, width+len(added))
+		for i, value := range row {
+			moved[r][cols[i]] = value
+		}
+	}
+	if l.headerRows == 0 {
+		// without a header of its own the table takes that of other
+		out = append(moved[:ol.headerRows:ol.headerRows], out...)
+	}
+	return append(out, moved[ol.headerRows:]...)
+}
+
+// ConcatColumns appends the columns of other to the right of the table, matching its rows to those of the table
+// by their label, which is in the first column unless given by LabelColumn. The label column of other is dropped.
+//
+// Header rows are guessed for each table unless given by HeaderRows, which then applies to both,
+// and are placed side by side aligned at the bottom.
+// Rows of other with a label not found in the table are added below the others, and rows without a label
+// are matched in order to those of the table without one. Labels are compared after normalizing
+// case, punctuation and whitespace, and each row of the table matches at most one row of other.
+func (t Table) ConcatColumns(other Table, opts ...LookupOption) Table {
+	l := newLookup(t, opts)
+	ol := newLookup(other, opts)
+	width := columnCount(t)
+	otherCols := make([]int, 0, columnCount(other))
+	for col := 0; col < columnCount(other); col++ {
+		if col != ol.labelColumn {
+			otherCols = append(otherCols, col)
+		}
+	}
+	right := other.Select(otherCols...)
+
+	label := func(row []string, col int) string {
+		if col < len(row) {
+			return normalize(row[col])
+		}
+		return ""
+	}
+	pad := func(row []string) []string {
+		values := make([]string, width+len(otherCols))
+		copy(values, row)
+		return values
+	}
+
+	// header rows, aligned at the bottom
+	headerRows := l.headerRows
+	if ol.headerRows > headerRows {
+		headerRows = ol.headerRows
+	}
+	var out Table
+	for i := 0; i < headerRows; i++ {
+		var values []string
+		if r := i - (headerRows - l.headerRows); r >= 0 {
+			values = pad(t[r])
+		} else {
+			values = pad(nil)
+		}
+		if r := i - (headerRows - ol.headerRows); r >= 0 {
+			copy(values[width:], right[r])
+		}
+		out = append(out, values)
+	}
+
+	used := make([]bool, len(other))
+	for _, row := range t[l.headerRows:] {
+		values := pad(row)
+		want := label(row, l.labelColumn)
+		for r := ol.headerRows; r < len(other); r++ {
+			if !used[r] && label(other[r], ol.labelColumn) == want {
+				used[r] = true
+				copy(values[width:], right[r])
+				break
+			}
+		}
+		out = append(out, values)
+	}
+	for r := ol.headerRows; r < len(other); r++ {
+		if used[r] {
+			continue
+		}
+		values := pad(nil)
+		if l.labelColumn < width && ol.labelColumn < len(other[r]) {
+			values[l.labelColumn] = other[r][ol.labelColumn]
+		}
+		copy(values[width:], right[r])
+		out = append(out, values)
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package htmltable
+
+import (
+	"strings"
+	"testing"
+)
+
+// raggedTable is parsed with rows of different lengths, as finishTable leaves rows with fewer cells
+const raggedTable = `<table>
+	<tr><td>Item</td><td>2023</td><td>2022</td></tr>
+	<tr><td>a</td></tr>
+	<tr><td rowspan="2">b</td><td>2</td><td>3</td><td>4</td></tr>
+	<tr><td>5</td></tr>
+</table>`
+
+func parseRagged(t *testing.T) Table {
+	ts, err := NewFromString(raggedTable)
+	assertNoError(t, err)
+	table := *ts[0]
+	assertEqual(t, Table{{"Item", "2023", "2022"}, {"a"}, {"b", "2", "3", "4"}, {"b", "5"}}, table)
+	return table
+}
+
+func TestTransposeAndSlice(t *testing.T) {
+	table := parseRagged(t)
+	assertEqual(t, Table{
+		{"Item", "a", "b", "b"},
+		{"2023", "", "2", "5"},
+		{"2022", "", "3", ""},
+		{"", "", "4", ""},
+	}, table.Transpose())
+	assertEqual(t, Table{{"2023", "2022"}, {}, {"2", "3"}}, table.Slice(0, 3, 1, 3))
+	assertEqual(t, Table{{"5"}}, table.Slice(3, 10, 1, 10))
+	assertEqual(t, Table{}, table.Slice(5, 2, 0, 1))
+	assertEqual(t, Table{}, Table{}.Transpose())
+}
+
+func TestFilterSelectDrop(t *testing.T) {
+	table := parseRagged(t)
+	assertEqual(t, Table{{"b", "2", "3", "4"}, {"b", "5"}}, table.Filter(func(row []string) bool {
+		return row[0] == "b"
+	}))
+	assertEqual(t, Table{{"2022", "Item"}, {"", "a"}, {"3", "b"}, {"", "b"}}, table.Select(2, 0))
+	assertEqual(t, Table{{"Item"}, {"a"}, {"b", "4"}, {"b"}}, table.DropColumns(1, 2))
+	upper := table.Map(func(row, col int, value string) string {
+		return strings.ToUpper(value)
+	})
+	assertEqual(t, "ITEM", upper[0][0])
+	assertEqual(t, 1, len(upper[1]))
+}
+
+func TestOperationsDoNotModify(t *testing.T) {
+	table := parseRagged(t)
+	copied := table.Copy()
+	copied[0][0] = "x"
+	table.Filter(func([]string) bool { return true })[0][0] = "x"
+	table.Slice(0, 1, 0, 1)[0][0] = "x"
+	table.Concat(Table{{"Item"}, {"c"}}, HeaderRows(1))[0][0] = "x"
+	assertEqual(t, "Item", table[0][0])
+	_ = append(table.Slice(0, 1, 0, 1)[0], "y")
+	assertEqual(t, "2023", table[0][1])
+}
+
+func TestConcat(t *testing.T) {
+	table := parseRagged(t)
+	other := Table{
+		{"2022", "Item", "2021"},
+		{"7", "c", "8"},
+		{"9"},
+	}
+	assertEqual(t, Table{
+		{"Item", "2023", "2022", "", "2021"},
+		{"a", "", "", "", ""},
+		{"b", "2", "3", "4", ""},
+		{"b", "5", "", "", ""},
+		{"c", "", "7", "", "8"},
+		{"", "", "9", "", ""},
+	}, table.Concat(other, HeaderRows(1)))
+	assertEqual(t, Table{{"Item", "2023", "2022"}, {"a"}, {"b", "2", "3", "4"}, {"b", "5"}, {"c", "", "7", ""}},
+		table.Concat(Table{{"ITEM", "2022"}, {"c", "7"}}, HeaderRows(1)))
+
+	// blank headers, as of label columns, match in order
+	assertEqual(t, Table{{"", "2023", "2022"}, {"Revenue", "1", ""}, {"Revenue", "", "3"}},
+		Table{{"", "2023"}, {"Revenue", "1"}}.Concat(Table{{"", "2022"}, {"Revenue", "3"}}))
+	// a table without a header takes that of other
+	assertEqual(t, Table{{"n"}, {"1"}}, Table{}.Concat(Table{{"n"}, {"1"}}, HeaderRows(1)))
+}
+
+func TestConcatColumns(t *testing.T) {
+	ts, err := NewFromString(`<table>
+		<tr><td></td><td colspan="2">Quarter</td></tr>
+		<tr><td></td><td>Q1</td><td>Q2</td></tr>
+		<tr><td>Revenue</td><td>1</td><td>2</td></tr>
+		<tr><td>Costs</td><td>3</td></tr>
+	</table>`)
+	assertNoError(t, err)
+	table := *ts[0]
+	other := Table{
+		{"", "Year"},
+		{"costs", "30"},
+		{"Other", "5"},
+		{"Revenue", "10"},
+	}
+	assertEqual(t, Table{
+		{"", "Quarter", "Quarter", ""},
+		{"", "Q1", "Q2", "Year"},
+		{"Revenue", "1", "2", "10"},
+		{"Costs", "3", "", "30"},
+		{"Other", "", "", "5"},
+	}, table.ConcatColumns(other))
+}
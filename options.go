@@ -0,0 +1,15 @@
+package htmltable
+
+// Option configures optional behaviour of the Parser
+type Option func(*Parser)
+
+// WithARIA enables recognition of tables built from non-table elements.
+//
+// Elements with role="table", "grid" or "treegrid" are treated as <table>,
+// role="row" as <tr> and role="cell", "gridcell", "columnheader" or "rowheader" as <td>.
+// Inline styles of display:table, table-row and table-cell are treated the same way.
+func WithARIA() Option {
+	return func(p *Parser) {
+		p.aria = true
+	}
+}
@@ -0,0 +1,295 @@
+package htmltable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// Parquet enums, as defined by parquet.thrift
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetOptional = 1
+
+	parquetUTF8 = 0
+	parquetDate = 6
+
+	parquetPlain = 0
+	parquetRLE   = 3
+
+	parquetDataPage = 0
+)
+
+// thrift compact protocol types
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter writes structs in the thrift compact protocol, which Parquet uses for its metadata
+type thriftWriter struct {
+	buf bytes.Buffer
+	// last is the id of the last field written in each open struct
+	last []int16
+}
+
+func (w *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func (w *thriftWriter) zigzag(v int64) {
+	w.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	last := &w.last[len(w.last)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.zigzag(int64(id))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) begin() {
+	w.last = append(w.last, 0)
+}
+
+func (w *thriftWriter) end() {
+	w.buf.WriteByte(0)
+	w.last = w.last[:len(w.last)-1]
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.zigzag(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.zigzag(v)
+}
+
+func (w *thriftWriter) str(id int16, s string) {
+	w.field(id, thriftBinary)
+	w.varint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+func (w *thriftWriter) list(id int16, typ byte, n int) {
+	w.field(id, thriftList)
+	if n < 15 {
+		w.buf.WriteByte(byte(n)<<4 | typ)
+	} else {
+		w.buf.WriteByte(0xf0 | typ)
+		w.varint(uint64(n))
+	}
+}
+
+// struct begins a struct field, which is closed by end
+func (w *thriftWriter) structField(id int16) {
+	w.field(id, thriftStruct)
+	w.begin()
+}
+
+// parquetColumn is a column chunk as written to the file
+type parquetColumn struct {
+	field     Field
+	physical  int32
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+// WriteParquet writes the rows of the table below its header to w as a Parquet file,
+// typed by the Schema of the table. Columns are optional, with blank values and placeholders such as dashes
+// and "n/a" written as nulls. Percentages are written as fractions, e.g. 12.5% as 0.125, as WriteXLSX does.
+//
+// The file holds a single row group of uncompressed, plain encoded pages, which every Parquet reader supports:
+// strings as UTF-8 byte arrays, dates as 32-bit days since 1970-01-01, numbers as 64-bit integers and doubles.
+func (t Table) WriteParquet(w io.Writer, opts ...LookupOption) error {
+	schema := t.Schema(opts...)
+	rows := t[schema.HeaderRows:]
+	var out bytes.Buffer
+	out.WriteString("PAR1")
+	var columns []parquetColumn
+	for _, field := range schema.Fields {
+		col := parquetColumn{
+			field:     field,
+			physical:  parquetPhysicalType(field.Type),
+			offset:    int64(out.Len()),
+			numValues: int64(len(rows)),
+		}
+		defined := make([]bool, len(rows))
+		var values bytes.Buffer
+		var bits []bool
+		for i, row := range rows {
+			value := ""
+			if field.Col < len(row) {
+				value = strings.TrimSpace(row[field.Col])
+			}
+			if value == "" || isPlaceholder(value) {
+				continue
+			}
+			switch field.Type {
+			case TypeString:
+				binary.Write(&values, binary.LittleEndian, uint32(len(value)))
+				values.WriteString(value)
+			case TypeInt64:
+				f, _ := ParseNumber(value)
+				binary.Write(&values, binary.LittleEndian, int64(f))
+			case TypeFloat64:
+				f, _ := fractionNumber(value)
+				binary.Write(&values, binary.LittleEndian, math.Float64bits(f))
+			case TypeDate:
+				tm, _ := ParseDate(value)
+				days := time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+				binary.Write(&values, binary.LittleEndian, int32(days))
+			case TypeBool:
+				b, _ := parseStrictBool(value)
+				bits = append(bits, b)
+			}
+			defined[i] = true
+		}
+		if field.Type == TypeBool {
+			values.Write(packBits(bits))
+		}
+
+		// definition levels of an optional column, each 0 for null or 1, length prefixed
+		levels := rleBitPacked(defined)
+		var page bytes.Buffer
+		binary.Write(&page, binary.LittleEndian, uint32(len(levels)))
+		page.Write(levels)
+		page.Write(values.Bytes())
+
+		var header thriftWriter
+		header.begin()
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(page.Len()))
+		header.i32(3, int32(page.Len()))
+		header.structField(5)
+		header.i32(1, int32(len(rows)))
+		header.i32(2, parquetPlain)
+		header.i32(3, parquetRLE)
+		header.i32(4, parquetRLE)
+		header.end()
+		header.end()
+
+		out.Write(header.buf.Bytes())
+		out.Write(page.Bytes())
+		col.size = int64(out.Len()) - col.offset
+		columns = append(columns, col)
+	}
+
+	var meta thriftWriter
+	meta.begin()
+	meta.i32(1, 1)
+	meta.list(2, thriftStruct, len(columns)+1)
+	meta.begin()
+	meta.str(4, "schema")
+	meta.i32(5, int32(len(columns)))
+	meta.end()
+	for _, col := range columns {
+		meta.begin()
+		meta.i32(1, col.physical)
+		meta.i32(3, parquetOptional)
+		meta.str(4, col.field.Name)
+		switch col.field.Type {
+		case TypeString:
+			meta.i32(6, parquetUTF8)
+		case TypeDate:
+			meta.i32(6, parquetDate)
+		}
+		meta.end()
+	}
+	meta.i64(3, int64(len(rows)))
+	meta.list(4, thriftStruct, 1)
+	meta.begin()
+	meta.list(1, thriftStruct, len(columns))
+	var total int64
+	for _, col := range columns {
+		total += col.size
+		meta.begin()
+		meta.i64(2, col.offset)
+		meta.structField(3)
+		meta.i32(1, col.physical)
+		meta.list(2, thriftI32, 2)
+		meta.zigzag(parquetPlain)
+		meta.zigzag(parquetRLE)
+		meta.list(3, thriftBinary, 1)
+		meta.varint(uint64(len(col.field.Name)))
+		meta.buf.WriteString(col.field.Name)
+		meta.i32(4, 0) // uncompressed
+		meta.i64(5, col.numValues)
+		meta.i64(6, col.size)
+		meta.i64(7, col.size)
+		meta.i64(9, col.offset)
+		meta.end()
+		meta.end()
+	}
+	meta.i64(2, total)
+	meta.i64(3, int64(len(rows)))
+	meta.end()
+	meta.str(6, "github.com/cel-edward/go-htmltable")
+	meta.end()
+
+	out.Write(meta.buf.Bytes())
+	binary.Write(&out, binary.LittleEndian, uint32(meta.buf.Len()))
+	out.WriteString("PAR1")
+	_, err := w.Write(out.Bytes())
+	if err != nil {
+		return fmt.Errorf("parquet: %w", err)
+	}
+	return nil
+}
+
+// parquetPhysicalType is the type a column is stored as
+func parquetPhysicalType(c ColumnType) int32 {
+	switch c {
+	case TypeInt64:
+		return parquetInt64
+	case TypeFloat64:
+		return parquetDouble
+	case TypeDate:
+		return parquetInt32
+	case TypeBool:
+		return parquetBoolean
+	}
+	return parquetByteArray
+}
+
+// packBits packs bits into bytes, least significant bit first
+func packBits(bits []bool) []byte {
+	packed := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		if bit {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	return packed
+}
+
+// rleBitPacked encodes levels of bit width 1 in the RLE/bit-packing hybrid encoding, as a single bit-packed run
+func rleBitPacked(levels []bool) []byte {
+	if len(levels) == 0 {
+		return nil
+	}
+	groups := (len(levels) + 7) / 8
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], uint64(groups<<1|1))
+	return append(b[:n], packBits(levels)...)
+}
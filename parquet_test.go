@@ -0,0 +1,177 @@
+package htmltable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// thriftReader reads the thrift compact protocol into maps of field ids to values, for checking written metadata
+type thriftReader struct {
+	t    *testing.T
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) varint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("bad varint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case 1:
+		return true
+	case 2:
+		return false
+	case 3:
+		r.pos++
+		return int64(r.data[r.pos-1])
+	case 4, 5, 6:
+		return r.zigzag()
+	case 7:
+		r.pos += 8
+		return math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos-8:]))
+	case 8:
+		n := int(r.varint())
+		r.pos += n
+		return string(r.data[r.pos-n : r.pos])
+	case 9, 10:
+		header := r.data[r.pos]
+		r.pos++
+		n := int(header >> 4)
+		if n == 15 {
+			n = int(r.varint())
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case 12:
+		fields := map[int16]interface{}{}
+		var last int16
+		for {
+			header := r.data[r.pos]
+			r.pos++
+			if header == 0 {
+				return fields
+			}
+			id := last + int16(header>>4)
+			if header>>4 == 0 {
+				id = int16(r.zigzag())
+			}
+			fields[id] = r.value(header & 0x0f)
+			last = id
+		}
+	}
+	r.t.Fatalf("unknown thrift type %d", typ)
+	return nil
+}
+
+func thriftStructAt(t *testing.T, data []byte, pos int) (map[int16]interface{}, int) {
+	r := &thriftReader{t: t, data: data, pos: pos}
+	s := r.value(12).(map[int16]interface{})
+	return s, r.pos
+}
+
+func TestWriteParquet(t *testing.T) {
+	table := Table{
+		{"Name", "Shares", "Price", "Listed", "Active"},
+		{"A", "1,200", "$ 10.50", "1970-01-03", "Yes"},
+		{"B", "", "12", "Jan 5, 1970", "no"},
+	}
+	var buf bytes.Buffer
+	assertNoError(t, table.WriteParquet(&buf))
+	data := buf.Bytes()
+	assertEqual(t, "PAR1", string(data[:4]))
+	assertEqual(t, "PAR1", string(data[len(data)-4:]))
+
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	meta, end := thriftStructAt(t, data, len(data)-8-size)
+	assertEqual(t, len(data)-8, end)
+	assertEqual(t, int64(2), meta[3])
+	schema := meta[2].([]interface{})
+	assertEqual(t, 6, len(schema))
+	root := schema[0].(map[int16]interface{})
+	assertEqual(t, "schema", root[4])
+	assertEqual(t, int64(5), root[5])
+	var names []interface{}
+	var types []interface{}
+	for _, el := range schema[1:] {
+		names = append(names, el.(map[int16]interface{})[4])
+		types = append(types, el.(map[int16]interface{})[1])
+	}
+	assertEqual(t, []interface{}{"Name", "Shares", "Price", "Listed", "Active"}, names)
+	assertEqual(t, []interface{}{int64(parquetByteArray), int64(parquetInt64), int64(parquetDouble), int64(parquetInt32), int64(parquetBoolean)}, types)
+
+	rowGroup := meta[4].([]interface{})[0].(map[int16]interface{})
+	assertEqual(t, int64(2), rowGroup[3])
+	columns := rowGroup[1].([]interface{})
+	assertEqual(t, 5, len(columns))
+
+	// read back the pages of the Shares and Listed columns
+	pages := map[int][]byte{}
+	for i, c := range columns {
+		colMeta := c.(map[int16]interface{})[3].(map[int16]interface{})
+		assertEqual(t, []interface{}{names[i]}, colMeta[3])
+		assertEqual(t, int64(2), colMeta[5])
+		header, pos := thriftStructAt(t, data, int(colMeta[9].(int64)))
+		pageSize := int(header[3].(int64))
+		assertEqual(t, int64(2), header[5].(map[int16]interface{})[1])
+		assertEqual(t, int(colMeta[9].(int64)+colMeta[7].(int64)), pos+pageSize)
+		pages[i] = data[pos : pos+pageSize]
+	}
+	// 4 byte length, bit-packed run header, levels of 1 and 0, then the single int64
+	assertEqual(t, []byte{2, 0, 0, 0, 3, 1, 176, 4, 0, 0, 0, 0, 0, 0}, pages[1])
+	// days since 1970-01-01
+	assertEqual(t, []byte{2, 0, 0, 0, 3, 3, 2, 0, 0, 0, 4, 0, 0, 0}, pages[3])
+	// bits of true and false
+	assertEqual(t, []byte{2, 0, 0, 0, 3, 3, 1}, pages[4])
+}
+
+func TestWriteParquetPlaceholders(t *testing.T) {
+	table := Table{
+		{"Change", "Shares"},
+		{"12.5%", "1"},
+		{"\u2014", "n/a"},
+	}
+	var buf bytes.Buffer
+	assertNoError(t, table.WriteParquet(&buf))
+	data := buf.Bytes()
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	meta, _ := thriftStructAt(t, data, len(data)-8-size)
+	columns := meta[4].([]interface{})[0].(map[int16]interface{})[1].([]interface{})
+	var pages [][]byte
+	for _, c := range columns {
+		colMeta := c.(map[int16]interface{})[3].(map[int16]interface{})
+		header, pos := thriftStructAt(t, data, int(colMeta[9].(int64)))
+		pages = append(pages, data[pos:pos+int(header[3].(int64))])
+	}
+	// the percentage as a fraction, as in WriteXLSX, and the dash as a null
+	levels := []byte{2, 0, 0, 0, 3, 1}
+	fraction := make([]byte, 8)
+	binary.LittleEndian.PutUint64(fraction, math.Float64bits(0.125))
+	assertEqual(t, append(levels, fraction...), pages[0])
+	assertEqual(t, append(levels, 1, 0, 0, 0, 0, 0, 0, 0), pages[1])
+}
+
+func TestThriftFieldDelta(t *testing.T) {
+	var w thriftWriter
+	w.begin()
+	w.i32(1, -1)
+	w.i64(20, 300)
+	w.str(21, "ab")
+	w.end()
+	assertEqual(t, []byte{0x15, 1, 0x06, 40, 0xd8, 0x04, 0x18, 2, 'a', 'b', 0}, w.buf.Bytes())
+}
@@ -3,7 +3,7 @@ package htmltable
 
 import (
 	"io"
-	"regexp"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -13,11 +13,46 @@ import (
 // mock for tests
 var htmlParse = html.Parse
 
+// Parser extracts tables from a html document.
+//
+// Tables and Meta are filled in document order, with Meta[i] describing Tables[i].
 type Parser struct {
 	Tables     []*Table
+	Meta       []*TableMeta
 	currentRow row
 	rows       []row
+	rowsHidden []bool
 	maxCols    int
+
+	// hidden is set while traversing the descendants of a hidden element, when WithHiddenIncluded
+	hidden      bool
+	rowHidden   bool
+	tableHidden bool
+	// inCell is set while reading the content of a cell
+	inCell bool
+
+	aria          bool
+	visibility    Visibility
+	includeHidden bool
+	styles        *stylesheet
+	textMode      TextMode
+	whitespace    Whitespace
+	nbspAsSpace   bool
+
+	footnotes        bool
+	resolveFootnotes bool
+	footnoteIndex    *footnoteIndex
+	baseURL          *url.URL
+	altText          bool
+	autoScale        bool
+	dropLayout       bool
+	stitch           bool
+	tableNode        *html.Node
+
+	definitionLists bool
+	// terms are the <dt> cells awaiting their definitions, defined is set once one was found
+	terms   []*Cell
+	defined bool
 }
 
 // Table contains the 2D slice of string data parsed from html.
@@ -25,31 +60,43 @@ type Parser struct {
 // Each string value is stripped of whitespace.
 type Table [][]string
 
-// cell is an internal structure for use in parsing, representing a <td> unit
-type cell struct {
-	Value   string
-	RowSpan int
-	ColSpan int
-}
-
 // row is an internal structure for use in parsing, representing a slice of cells
-type row []cell
+type row []*Cell
 
 // New returns an instance of the page with possibly more than one table
-func New(r io.Reader) ([]*Table, error) {
-	parser := Parser{
-		Tables: nil,
+func New(r io.Reader, opts ...Option) ([]*Table, error) {
+	parser, err := Parse(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return parser.Tables, nil
+}
+
+// NewFromString is same as New(io.Reader, ...Option), but from string
+func NewFromString(r string, opts ...Option) ([]*Table, error) {
+	return New(strings.NewReader(r), opts...)
+}
+
+// Parse is same as New(io.Reader, ...Option), but returns the Parser
+// so that the metadata of each table is also available
+func Parse(r io.Reader, opts ...Option) (*Parser, error) {
+	parser := &Parser{
+		Tables:     nil,
+		visibility: DefaultVisibility,
+	}
+	for _, opt := range opts {
+		opt(parser)
 	}
 	err := parser.parse(r)
 	if err != nil {
 		return nil, err
 	}
-	return parser.Tables, nil
+	return parser, nil
 }
 
-// NewFromString is same as New(ctx.Context, io.Reader), but from string
-func NewFromString(r string) ([]*Table, error) {
-	return New(strings.NewReader(r))
+// ParseString is same as Parse(io.Reader, ...Option), but from string
+func ParseString(r string, opts ...Option) (*Parser, error) {
+	return Parse(strings.NewReader(r), opts...)
 }
 
 func (p *Parser) parse(r io.Reader) error {
@@ -57,8 +104,21 @@ func (p *Parser) parse(r io.Reader) error {
 	if err != nil {
 		return err
 	}
+	if p.visibility&VisibilityStylesheets != 0 {
+		p.styles = collectStylesheet(root)
+	}
+	if p.footnotes {
+		p.buildFootnoteIndex(root)
+	}
+	p.findBaseURL(root)
 	p.traverse(root)
 	p.finishTable()
+	if p.stitch {
+		p.stitchTables()
+	}
+	if p.dropLayout {
+		p.dropLayoutTables()
+	}
 	return nil
 }
 
@@ -67,59 +127,205 @@ func (p *Parser) traverse(n *html.Node) {
 	if n == nil {
 		return
 	}
-	switch n.Data {
-	case "td", "th":
-		rowspan, colspan, isDisplayNone := getAttributes(n)
-		if isDisplayNone {
+	kind := p.kindOf(n)
+	if p.isHidden(n) {
+		// hidden cells are always dropped, as keeping them would misalign columns
+		if !p.includeHidden || kind == kindCell {
 			return
 		}
-		var sb strings.Builder
-		getInnerText(n, &sb)
-		cell := cell{
-			Value:   strings.TrimSpace(sb.String()),
-			ColSpan: colspan,
-			RowSpan: rowspan,
+		prev := p.hidden
+		p.hidden = true
+		defer func() {
+			p.hidden = prev
+		}()
+	}
+	switch kind {
+	case kindCell:
+		p.currentRow = append(p.currentRow, p.newCell(n))
+		return
+	case kindTerm:
+		if p.defined {
+			p.terms, p.defined = nil, false
 		}
-		p.currentRow = append(p.currentRow, cell)
+		p.terms = append(p.terms, p.newCell(n))
 		return
-	case "tr":
+	case kindDefinition:
+		definition := p.newCell(n)
+		if len(p.terms) == 0 {
+			p.terms = []*Cell{{RowSpan: 1, ColSpan: 1}}
+		}
+		for i, term := range p.terms {
+			p.finishRow()
+			p.rowHidden = p.hidden
+			// terms with several definitions, and definitions of several terms, are repeated on each row
+			if p.defined {
+				copied := *term
+				term = &copied
+			}
+			if i > 0 {
+				copied := *definition
+				definition = &copied
+			}
+			p.currentRow = append(p.currentRow, term, definition)
+		}
+		p.defined = true
+		return
+	case kindRow:
 		p.finishRow()
-	case "table":
+		p.rowHidden = p.hidden
+	case kindTable:
 		p.finishTable()
+		p.tableHidden = p.hidden
+		p.tableNode = n
 	}
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
 		p.traverse(c)
 	}
 }
 
+// newCell reads the cell node n
+func (p *Parser) newCell(n *html.Node) *Cell {
+	p.inCell = true
+	defer func() {
+		p.inCell = false
+	}()
+	rowspan, colspan := getAttributes(n)
+	cell := &Cell{
+		Value:   p.cellText(n),
+		ColSpan: colspan,
+		RowSpan: rowspan,
+		Header:  isHeaderCell(n),
+		Style:   p.cellStyle(n),
+	}
+	p.collectReferences(n, cell)
+	if p.footnotes {
+		cell.Value, cell.Footnotes = p.cellFootnotes(n, cell.Value)
+	}
+	if p.altText && cell.Value == "" {
+		cell.Value = cell.altText()
+	}
+	return cell
+}
+
+// nodeKind is the role a node plays in the structure of a table
+type nodeKind int
+
+const (
+	kindOther nodeKind = iota
+	kindTable
+	kindRow
+	kindCell
+	kindTerm       // <dt>, when WithDefinitionLists
+	kindDefinition // <dd>, when WithDefinitionLists
+)
+
+// kindOf returns the nodeKind of n.
+//
+// Native table elements are always recognised, while ARIA roles and display:table* styles
+// are only considered when the parser was created WithARIA.
+func (p *Parser) kindOf(n *html.Node) nodeKind {
+	if n.Type != html.ElementNode {
+		return kindOther
+	}
+	switch n.Data {
+	case "td", "th":
+		return kindCell
+	case "tr":
+		return kindRow
+	case "table":
+		return kindTable
+	}
+	if p.definitionLists {
+		switch n.Data {
+		case "dl":
+			return kindTable
+		case "dt":
+			return kindTerm
+		case "dd":
+			return kindDefinition
+		}
+	}
+	if !p.aria {
+		return kindOther
+	}
+	for _, a := range n.Attr {
+		switch strings.ToLower(a.Key) {
+		case "role":
+			// the role attribute is a list of tokens, the first recognised one applies
+			for _, role := range strings.Fields(strings.ToLower(a.Val)) {
+				switch role {
+				case "table", "grid", "treegrid":
+					return kindTable
+				case "row":
+					return kindRow
+				case "cell", "gridcell", "columnheader", "rowheader":
+					return kindCell
+				}
+			}
+		case "style":
+			switch parseDeclarations(a.Val)["display"] {
+			case "table", "inline-table":
+				return kindTable
+			case "table-row":
+				return kindRow
+			case "table-cell":
+				return kindCell
+			}
+		}
+	}
+	return kindOther
+}
+
+// isHeaderCell reports whether cell node n is a <th>, a <dt> or has a header role
+func isHeaderCell(n *html.Node) bool {
+	if n.Data == "th" || n.Data == "dt" {
+		return true
+	}
+	for _, role := range strings.Fields(strings.ToLower(getAttr(n, "role"))) {
+		if role == "columnheader" || role == "rowheader" {
+			return true
+		}
+	}
+	return false
+}
+
 // getAttributes returns attributes for node n that are relevant for parsing,
-// namely rowspan, colspan, and display:none
+// namely rowspan and colspan
+//
+// aria-rowspan and aria-colspan are used when the native attributes are absent.
 //
-// If not found, defaults returned are row/colspan = 1 and isDisplayNone = false
-func getAttributes(n *html.Node) (rowspan int, colspan int, isDisplayNone bool) {
+// If not found, defaults returned are row/colspan = 1
+func getAttributes(n *html.Node) (rowspan int, colspan int) {
 	colspan = 1
 	rowspan = 1
-	isDisplayNone = false
-	displayNoneRegexp := regexp.MustCompile(`display:\s*none`)
+	hasColspan, hasRowspan := false, false
 	for _, a := range n.Attr {
 		key := strings.ToLower(a.Key)
 		if key == "colspan" {
 			val, err := strconv.Atoi(a.Val)
 			if err == nil {
 				colspan = val
+				hasColspan = true
 			}
 		} else if key == "rowspan" {
 			val, err := strconv.Atoi(a.Val)
 			if err == nil {
 				rowspan = val
+				hasRowspan = true
 			}
-		} else if key == "style" {
-			if displayNoneRegexp.Match([]byte(a.Val)) {
-				isDisplayNone = true
+		} else if key == "aria-colspan" && !hasColspan {
+			val, err := strconv.Atoi(a.Val)
+			if err == nil {
+				colspan = val
+			}
+		} else if key == "aria-rowspan" && !hasRowspan {
+			val, err := strconv.Atoi(a.Val)
+			if err == nil {
+				rowspan = val
 			}
 		}
 	}
-	return rowspan, colspan, isDisplayNone
+	return rowspan, colspan
 }
 
 // finishRow handles the end of a <tr> block in the html, shifting the data into the parser's rows buffer
@@ -131,6 +337,7 @@ func (p *Parser) finishRow() {
 		p.maxCols = len(p.currentRow)
 	}
 	p.rows = append(p.rows, p.currentRow)
+	p.rowsHidden = append(p.rowsHidden, p.rowHidden)
 	p.currentRow = row{}
 }
 
@@ -139,24 +346,34 @@ func (p *Parser) finishRow() {
 // and the data is appended to parser.Tables
 func (p *Parser) finishTable() {
 
+	// terms left without a definition get a row of their own
+	if !p.defined {
+		for _, term := range p.terms {
+			p.finishRow()
+			p.currentRow = append(p.currentRow, term)
+		}
+	}
+	p.terms, p.defined = nil, false
 	p.finishRow()
 	if len(p.rows) == 0 {
 		return
 	}
 
 	tableData := [][]string{}
+	tableCells := [][]*Cell{}
 
 	// carryover handles row spans > 1, by keeping track of cells that need to be handled
 	// in subsequent rows during the main row loop
 	type carryover struct {
-		Value   string // value of cell
-		Index   int    // column index of the carryover
-		RowSpan int    // how many spans left still in the carryover
+		Cell    *Cell // cell being carried over
+		Index   int   // column index of the carryover
+		RowSpan int   // how many spans left still in the carryover
 	}
 	var rowCarryover []carryover
 
-	for _, row := range p.rows {
+	for rowIndex, row := range p.rows {
 		var rowData []string
+		var rowCells []*Cell
 		nextRowCarryover := []carryover{}
 		currentIndex := 0
 
@@ -172,11 +389,12 @@ func (p *Parser) finishTable() {
 					rowCarryover = nil
 				}
 
-				rowData = append(rowData, co.Value)
+				rowData = append(rowData, co.Cell.Value)
+				rowCells = append(rowCells, co.Cell)
 				// add to the next row if there is still additional rowspan
 				if co.RowSpan > 1 {
 					nextRowCarryover = append(nextRowCarryover, carryover{
-						Value:   co.Value,
+						Cell:    co.Cell,
 						RowSpan: co.RowSpan - 1,
 						Index:   co.Index,
 					})
@@ -184,12 +402,15 @@ func (p *Parser) finishTable() {
 			}
 
 			// now we can start copying values from the current cell
+			cell.Row = rowIndex
+			cell.Col = len(rowData)
 			for i := 0; i < cell.ColSpan; i++ {
 				rowData = append(rowData, cell.Value)
+				rowCells = append(rowCells, cell)
 				// account for rowspan into subsequent rows
 				if cell.RowSpan > 1 {
 					co := carryover{
-						Value:   cell.Value,
+						Cell:    cell,
 						RowSpan: cell.RowSpan - 1,
 						Index:   currentIndex,
 					}
@@ -201,11 +422,12 @@ func (p *Parser) finishTable() {
 
 		// this is for any columns that only exist at the bottom due to rowspan (i.e. no <td> standalone)
 		for _, co := range rowCarryover {
-			rowData = append(rowData, co.Value)
+			rowData = append(rowData, co.Cell.Value)
+			rowCells = append(rowCells, co.Cell)
 			// add to the next row if there is still additional rowspan
 			if co.RowSpan > 1 {
 				nextRowCarryover = append(nextRowCarryover, carryover{
-					Value:   co.Value,
+					Cell:    co.Cell,
 					RowSpan: co.RowSpan - 1,
 					Index:   co.Index,
 				})
@@ -213,19 +435,65 @@ func (p *Parser) finishTable() {
 		}
 
 		tableData = append(tableData, rowData)
+		tableCells = append(tableCells, rowCells)
 		rowCarryover = nextRowCarryover
 	}
 	newTable := Table(tableData)
 	p.Tables = append(p.Tables, &newTable)
 
+	meta := &TableMeta{
+		Index:  len(p.Meta),
+		Hidden: p.tableHidden,
+		Cells:  tableCells,
+	}
+	for i, hidden := range p.rowsHidden {
+		if hidden {
+			meta.HiddenRows = append(meta.HiddenRows, i)
+		}
+	}
+	if p.tableNode != nil {
+		meta.Caption = p.tableCaption(p.tableNode)
+		meta.Heading = p.tableHeading(p.tableNode)
+		meta.Preceding = p.precedingText(p.tableNode)
+		meta.pageBreak = p.pageBreakBefore(p.tableNode)
+	}
+	meta.Unit = tableUnit(newTable, meta)
+	meta.DataScore = p.dataScore(newTable, meta, p.tableNode)
+	meta.Layout = meta.DataScore < 0
+	if p.autoScale {
+		for _, row := range tableCells {
+			label := ""
+			if len(row) > 0 {
+				label = row[0].Value
+			}
+			for _, cell := range row {
+				cell.scale = meta.Unit.scaleFor(label, cell.Value)
+			}
+		}
+	}
+	p.Meta = append(p.Meta, meta)
+
 	p.maxCols = 0
 	p.currentRow = row{}
 	p.rows = nil
+	p.rowsHidden = nil
+	p.tableNode = nil
+}
+
+// cellText returns the text value of cell node n according to the parser's TextMode
+func (p *Parser) cellText(n *html.Node) string {
+	if p.textMode == TextBlock {
+		return p.renderText(n)
+	}
+	var sb strings.Builder
+	p.getInnerText(n, &sb)
+	return strings.TrimSpace(sb.String())
 }
 
 // getInnerText retrieves any text from child nodes of n and adds it to sb.
 // Texts from different nodes will have a whitespace inserted between.
-func getInnerText(n *html.Node, sb *strings.Builder) {
+// Hidden child elements are skipped, as are footnote markers when parsing WithFootnotes.
+func (p *Parser) getInnerText(n *html.Node, sb *strings.Builder) {
 	if n.Type == html.TextNode {
 		sb.WriteString(strings.TrimSpace(n.Data))
 		sb.WriteString(" ")
@@ -235,7 +503,13 @@ func getInnerText(n *html.Node, sb *strings.Builder) {
 		return
 	}
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		getInnerText(c, sb)
+		if p.isHidden(c) {
+			continue
+		}
+		if _, ok := p.footnoteMarker(c); ok {
+			continue
+		}
+		p.getInnerText(c, sb)
 	}
 }
 
@@ -45,6 +45,51 @@ func TestInitFails(t *testing.T) {
 	assertEqualError(t, err, "nope")
 }
 
+func TestARIA(t *testing.T) {
+	ts, err := NewFromString(testTableARIA)
+	assertNoError(t, err)
+	assertEqual(t, len(ts), 0)
+
+	ts, err = NewFromString(testTableARIA, WithARIA())
+	assertNoError(t, err)
+	assertEqual(t, len(ts), 2)
+	assertEqual(t, *ts[0], Table{
+		{"Ticker", "Ticker", "Price"},
+		{"UPST", "Upstart", "30.12"},
+		{"KDP", "Keurig Dr Pepper", "30.12"},
+	})
+	assertEqual(t, *ts[1], Table{
+		{"a", "b"},
+		{"1", "2"},
+	})
+}
+
+const testTableARIA = `<body>
+<div role="table">
+	<div role="rowgroup">
+		<div role="row">
+			<span role="columnheader" aria-colspan="2">Ticker</span>
+			<span role="columnheader">Price</span>
+		</div>
+	</div>
+	<div role="rowgroup">
+		<div role="row">
+			<span role="rowheader">UPST</span>
+			<span role="cell">Upstart</span>
+			<span role="gridcell" aria-rowspan="2">30.12</span>
+		</div>
+		<div role="row">
+			<span role="rowheader">KDP</span>
+			<span role="cell">Keurig Dr Pepper</span>
+		</div>
+	</div>
+</div>
+<div style="display: table">
+	<div style="display: table-row"><div style="display: table-cell">a</div><div style="display:table-cell">b</div></div>
+	<div style="display: table-row"><div style="display: table-cell">1</div><div style="display:table-cell">2</div></div>
+</div>
+</body>`
+
 const testTable1 = `<body>
 <h1>foo</h2>
 <table>
@@ -0,0 +1,274 @@
+package htmltable
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is a reporting period, as defined by a column header such as
+// "Three Months Ended September 30, 2023", "FY2022", "Q3 2023" or "As of December 31, 2023"
+type Period struct {
+	// Text is the header the period was read from
+	Text string
+	// Start and End are the first and last days of the period, which are the same for an Instant
+	Start time.Time
+	End   time.Time
+	// Months and Weeks are the length of the period as written, only one of which is set
+	Months int
+	Weeks  int
+	// Instant is set for a point in time, such as the date of a balance sheet
+	Instant bool
+	// Fiscal is set for fiscal years and quarters. Their dates assume a fiscal year ending on December 31,
+	// as the actual year end is not part of the header.
+	Fiscal bool
+}
+
+// Duration returns the length of the period, which is 0 for an Instant
+func (p Period) Duration() time.Duration {
+	if p.Instant {
+		return 0
+	}
+	return p.End.AddDate(0, 0, 1).Sub(p.Start)
+}
+
+// numberWords are the numbers from one to nineteen, and tensWords the multiples of ten,
+// which together write the lengths of periods in words, e.g. "Thirty-Six Months"
+var (
+	numberWords = map[string]int{
+		"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9,
+		"ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16,
+		"seventeen": 17, "eighteen": 18, "nineteen": 19,
+	}
+	tensWords = map[string]int{
+		"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50, "sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	}
+)
+
+// periodQualifiers are the words that may come before the unit of a period without giving its length,
+// e.g. "For the Year Ended" or "Fiscal Year Ended"
+var periodQualifiers = map[string]bool{
+	"the": true, "for": true, "fiscal": true, "calendar": true, "current": true, "prior": true,
+	"previous": true, "same": true, "each": true, "comparable": true,
+}
+
+// ordinalWords are the quarters and halves written in words
+var ordinalWords = map[string]int{"first": 1, "second": 2, "third": 3, "fourth": 4, "1st": 1, "2nd": 2, "3rd": 3, "4th": 4}
+
+var (
+	// endedRegexp matches a period ended at a date, e.g. "Three Months Ended", "52 Weeks Ending", "Quarter Ended"
+	// The text before the unit is captured whole, as the word closest to the unit must be its length or a qualifier.
+	endedRegexp = regexp.MustCompile(`(?i)^(.*?)\b(month|week|quarter|half[- ]year|year)s?\s+(?:period\s+)?end(?:ed|ing)\b[\s:,]*(.*)$`)
+	// instantRegexp matches a point in time, e.g. "As of December 31, 2023" or "Balance at June 30, 2023"
+	instantRegexp = regexp.MustCompile(`(?i)^(?:(?:balances?\s+)?(?:as\s+(?:of|at)|at|on)\b)?[\s:,]*(.+?)[\s:,]*$`)
+	// textDateRegexp matches a date within some text
+	textDateRegexp = regexp.MustCompile(`(?i)\b(?:[a-z]{3,9}\.?\s+\d{1,2}(?:st|nd|rd|th)?,?\s+\d{4}|\d{1,2}(?:st|nd|rd|th)?\s+[a-z]{3,9}\.?,?\s+\d{4}|\d{4}-\d{1,2}-\d{1,2}|\d{1,2}[/.]\d{1,2}[/.]\d{2,4})\b`)
+	// quarterRegexp matches quarters, e.g. "Q3 2023", "3Q23", "Q3 FY2023", "Third Quarter 2023", "2023 Q3"
+	quarterRegexp = regexp.MustCompile(`(?i)^(?:q([1-4])\s*(fy|fiscal)?\s*'?(\d{4}|\d{2})|([1-4])q\s*(fy|fiscal)?\s*'?(\d{4}|\d{2})|(first|second|third|fourth|1st|2nd|3rd|4th)\s+(?:fiscal\s+)?quarter(?:\s+of)?\s+(fy|fiscal)?\s*(\d{4})|(fy|fiscal)?\s*(\d{4})\s*q([1-4]))$`)
+	// halfRegexp matches half years, e.g. "H1 2023", "1H23", "First Half 2023"
+	halfRegexp = regexp.MustCompile(`(?i)^(?:h([12])\s*(fy|fiscal)?\s*'?(\d{4}|\d{2})|([12])h\s*(fy|fiscal)?\s*'?(\d{4}|\d{2})|(first|second|1st|2nd)\s+half(?:\s+of)?\s+(fy|fiscal)?\s*(\d{4}))$`)
+	// yearRegexp matches years, e.g. "FY2022", "FY 22", "Fiscal Year 2022", "Fiscal 2022", "2022"
+	yearRegexp = regexp.MustCompile(`(?i)^(?:(fy|fiscal(?:\s+year)?|calendar(?:\s+year)?|year)\s*'?(\d{4}|\d{2})|(\d{4})(\s*fy|\s+fiscal(?:\s+year)?)?)$`)
+)
+
+// ParsePeriod parses a column header defining a reporting period:
+//   - periods ended at a date, e.g. "Three Months Ended September 30, 2023", "52 Weeks Ended January 28, 2023",
+//     "Quarter Ended September 30, 2023" or "Years Ended December 31, 2023"
+//   - points in time, e.g. "As of December 31, 2023", "At June 30, 2023" or "December 31, 2023"
+//   - quarters, e.g. "Q3 2023", "3Q23", "Q3 FY2023" or "Third Quarter 2023"
+//   - half years, e.g. "H1 2023" or "First Half 2023"
+//   - months, e.g. "September 2023"
+//   - years, e.g. "FY2022", "Fiscal 2022" or "2022"
+//
+// Headers missing the year, such as "As of December 31,", are not periods on their own,
+// see ParsePeriodPath for those split over several header rows.
+func ParsePeriod(text string) (Period, bool) {
+	s := strings.Join(strings.Fields(strings.ReplaceAll(text, "\u00a0", " ")), " ")
+	p := Period{Text: strings.TrimSpace(text)}
+	if s == "" {
+		return p, false
+	}
+
+	if m := endedRegexp.FindStringSubmatch(s); m != nil {
+		end, ok := ParseDate(m[3])
+		if !ok {
+			date := textDateRegexp.FindString(m[3])
+			if end, ok = ParseDate(date); !ok {
+				return p, false
+			}
+		}
+		count, ok := countBefore(m[1])
+		if !ok {
+			return p, false
+		}
+		switch unit := strings.ToLower(m[2]); {
+		case unit == "week":
+			p.Weeks = count
+			p.Start = end.AddDate(0, 0, 1-7*count)
+		case unit == "month":
+			p.Months = count
+		case unit == "quarter":
+			p.Months = 3 * count
+		case unit == "year":
+			p.Months = 12 * count
+		default:
+			p.Months = 6 * count
+		}
+		p.End = end
+		if p.Months > 0 {
+			p.Start = monthsBefore(end, p.Months)
+		}
+		return p, true
+	}
+
+	if m := quarterRegexp.FindStringSubmatch(s); m != nil {
+		quarter, fiscal, year := firstOf(m[1], m[4], m[7], m[12]), firstOf(m[2], m[5], m[8], m[10]), firstOf(m[3], m[6], m[9], m[11])
+		return p.span(fullYear(year), ordinal(quarter)*3-2, 3, fiscal != ""), true
+	}
+	if m := halfRegexp.FindStringSubmatch(s); m != nil {
+		half, fiscal, year := firstOf(m[1], m[4], m[7]), firstOf(m[2], m[5], m[8]), firstOf(m[3], m[6], m[9])
+		return p.span(fullYear(year), ordinal(half)*6-5, 6, fiscal != ""), true
+	}
+	if m := yearRegexp.FindStringSubmatch(s); m != nil {
+		year := fullYear(firstOf(m[2], m[3]))
+		if year < 1900 || year > 2100 {
+			return p, false
+		}
+		prefix := strings.ToLower(m[1])
+		fiscal := strings.HasPrefix(prefix, "f") || m[4] != ""
+		return p.span(year, 1, 12, fiscal), true
+	}
+	if t, err := time.Parse("January 2006", strings.TrimSuffix(septRegexp.ReplaceAllString(s, "Sep"), ",")); err == nil {
+		return p.span(t.Year(), int(t.Month()), 1, false), true
+	}
+	if t, err := time.Parse("Jan 2006", strings.ReplaceAll(septRegexp.ReplaceAllString(s, "Sep"), ".", "")); err == nil {
+		return p.span(t.Year(), int(t.Month()), 1, false), true
+	}
+
+	if m := instantRegexp.FindStringSubmatch(s); m != nil {
+		if date, ok := ParseDate(m[1]); ok {
+			p.Start, p.End, p.Instant = date, date, true
+			return p, true
+		}
+	}
+	return p, false
+}
+
+// ParsePeriodPath parses the header of a column split over several rows, e.g. ["Three Months Ended September 30,", "2023"]
+// or ["Year Ended December 31,", "2023", "Amount"], as found in Table.HeaderPaths.
+// The rows are tried joined together from the top, dropping rows from the bottom, then each row on its own from the bottom.
+func ParsePeriodPath(path []string) (Period, bool) {
+	for n := len(path); n > 0; n-- {
+		if p, ok := ParsePeriod(strings.Join(path[:n], " ")); ok {
+			return p, true
+		}
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		if p, ok := ParsePeriod(path[i]); ok {
+			return p, true
+		}
+	}
+	return Period{}, false
+}
+
+// Periods returns the period of each column of the table, read from its header with ParsePeriodPath,
+// or nil for the columns without one. Header rows are guessed unless given by HeaderRows.
+func (t Table) Periods(opts ...LookupOption) []*Period {
+	paths := t.HeaderPaths(opts...)
+	periods := make([]*Period, len(paths))
+	for col, path := range paths {
+		if p, ok := ParsePeriodPath(path); ok {
+			periods[col] = &p
+		}
+	}
+	return periods
+}
+
+// span sets the period to the months from month of year, and returns it
+func (p Period) span(year, month, months int, fiscal bool) Period {
+	p.Start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	p.End = p.Start.AddDate(0, months, -1)
+	p.Months = months
+	p.Fiscal = fiscal
+	return p
+}
+
+// monthsBefore returns the first day of the period of months ending on end.
+// Periods ending on the last day of a month start on the first day of a month.
+func monthsBefore(end time.Time, months int) time.Time {
+	if end.AddDate(0, 0, 1).Day() == 1 {
+		return time.Date(end.Year(), end.Month()-time.Month(months)+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return end.AddDate(0, -months, 1)
+}
+
+// countBefore reads the length of a period from the text before its unit, e.g. "For the Three" or "Thirty-Six".
+// Without any words, or with a qualifier closest to the unit, the length is 1.
+// Any other word closest to the unit must be a length.
+func countBefore(s string) (int, bool) {
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(s, "-", " ")))
+	if len(words) == 0 {
+		return 1, true
+	}
+	if len(words) >= 2 {
+		n, ok := periodCount(strings.Join(words[len(words)-2:], " "))
+		if _, tens := tensWords[words[len(words)-2]]; ok || tens {
+			return n, ok
+		}
+	}
+	last := words[len(words)-1]
+	if periodQualifiers[last] {
+		return 1, true
+	}
+	return periodCount(last)
+}
+
+// periodCount reads the length of a period written in digits or words, e.g. "3", "three", "eighteen" or "thirty-six"
+func periodCount(s string) (int, bool) {
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(s, "-", " ")))
+	switch len(words) {
+	case 1:
+		if n, err := strconv.Atoi(words[0]); err == nil {
+			return n, n > 0
+		}
+		if n, ok := numberWords[words[0]]; ok {
+			return n, true
+		}
+		n, ok := tensWords[words[0]]
+		return n, ok
+	case 2:
+		tens, ok := tensWords[words[0]]
+		if n, ok2 := numberWords[words[1]]; ok && ok2 && n < 10 {
+			return tens + n, true
+		}
+	}
+	return 0, false
+}
+
+// ordinal reads a quarter or half written as a digit or word
+func ordinal(s string) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return ordinalWords[strings.ToLower(s)]
+}
+
+// fullYear reads a year, expanding two digit years as ParseDate does
+func fullYear(s string) int {
+	year, _ := strconv.Atoi(s)
+	if len(s) == 2 {
+		year = expandYear(year)
+	}
+	return year
+}
+
+// firstOf returns the first of values that is not empty
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
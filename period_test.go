@@ -0,0 +1,87 @@
+package htmltable
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParsePeriod(t *testing.T) {
+	for _, tc := range []struct {
+		text   string
+		period Period
+	}{
+		{"Three Months Ended September 30, 2023", Period{Start: date(2023, 7, 1), End: date(2023, 9, 30), Months: 3}},
+		{"For the Six Months Ended June 30, 2023", Period{Start: date(2023, 1, 1), End: date(2023, 6, 30), Months: 6}},
+		{"Nine months ending Sept. 30, 2023", Period{Start: date(2023, 1, 1), End: date(2023, 9, 30), Months: 9}},
+		{"12 Months Ended March 15, 2023", Period{Start: date(2022, 3, 16), End: date(2023, 3, 15), Months: 12}},
+		{"Years Ended December 31, 2023 and 2022", Period{Start: date(2023, 1, 1), End: date(2023, 12, 31), Months: 12}},
+		{"Quarter Ended March 31, 2023", Period{Start: date(2023, 1, 1), End: date(2023, 3, 31), Months: 3}},
+		{"52 Weeks Ended January 28, 2023", Period{Start: date(2022, 1, 30), End: date(2023, 1, 28), Weeks: 52}},
+		{"Thirty-Six Months Ended June 30, 2023", Period{Start: date(2020, 7, 1), End: date(2023, 6, 30), Months: 36}},
+		{"Eighteen Months Ended June 30, 2023", Period{Start: date(2022, 1, 1), End: date(2023, 6, 30), Months: 18}},
+		{"Seventeen Weeks Ended April 29, 2023", Period{Start: date(2023, 1, 1), End: date(2023, 4, 29), Weeks: 17}},
+		{"Twenty Four Weeks Ended June 17, 2023", Period{Start: date(2023, 1, 1), End: date(2023, 6, 17), Weeks: 24}},
+		{"For the Year Ended December 31, 2023", Period{Start: date(2023, 1, 1), End: date(2023, 12, 31), Months: 12}},
+		{"Thirteen Weeks Ended April 1, 2023", Period{Start: date(2023, 1, 1), End: date(2023, 4, 1), Weeks: 13}},
+		{"As of December 31, 2023", Period{Start: date(2023, 12, 31), End: date(2023, 12, 31), Instant: true}},
+		{"Balance at June 30, 2023", Period{Start: date(2023, 6, 30), End: date(2023, 6, 30), Instant: true}},
+		{"December 31, 2022", Period{Start: date(2022, 12, 31), End: date(2022, 12, 31), Instant: true}},
+		{"Q3 2023", Period{Start: date(2023, 7, 1), End: date(2023, 9, 30), Months: 3}},
+		{"3Q23", Period{Start: date(2023, 7, 1), End: date(2023, 9, 30), Months: 3}},
+		{"Q4 FY2023", Period{Start: date(2023, 10, 1), End: date(2023, 12, 31), Months: 3, Fiscal: true}},
+		{"Third Quarter 2023", Period{Start: date(2023, 7, 1), End: date(2023, 9, 30), Months: 3}},
+		{"2023 Q1", Period{Start: date(2023, 1, 1), End: date(2023, 3, 31), Months: 3}},
+		{"H2 2023", Period{Start: date(2023, 7, 1), End: date(2023, 12, 31), Months: 6}},
+		{"First Half 2023", Period{Start: date(2023, 1, 1), End: date(2023, 6, 30), Months: 6}},
+		{"FY2022", Period{Start: date(2022, 1, 1), End: date(2022, 12, 31), Months: 12, Fiscal: true}},
+		{"Fiscal Year 22", Period{Start: date(2022, 1, 1), End: date(2022, 12, 31), Months: 12, Fiscal: true}},
+		{"FY99", Period{Start: date(1999, 1, 1), End: date(1999, 12, 31), Months: 12, Fiscal: true}},
+		{"Q3 98", Period{Start: date(1998, 7, 1), End: date(1998, 9, 30), Months: 3}},
+		{"1H05", Period{Start: date(2005, 1, 1), End: date(2005, 6, 30), Months: 6}},
+		{"2021", Period{Start: date(2021, 1, 1), End: date(2021, 12, 31), Months: 12}},
+		{"September 2023", Period{Start: date(2023, 9, 1), End: date(2023, 9, 30), Months: 1}},
+		{"Feb. 2024", Period{Start: date(2024, 2, 1), End: date(2024, 2, 29), Months: 1}},
+	} {
+		p, ok := ParsePeriod(tc.text)
+		assertEqual(t, true, ok)
+		tc.period.Text = tc.text
+		assertEqual(t, tc.period, p)
+	}
+	for _, s := range []string{"", "As of December 31,", "Three Months Ended September 30,", "Revenue", "Total", "1,234", "Q5 2023", "3000",
+		"Several Months Ended June 30, 2023", "Hundred Months Ended June 30, 2023", "Ninety-Ten Weeks Ended June 30, 2023"} {
+		_, ok := ParsePeriod(s)
+		assertEqual(t, false, ok)
+	}
+}
+
+func TestPeriodDuration(t *testing.T) {
+	p, _ := ParsePeriod("Q1 2023")
+	assertEqual(t, 90*24*time.Hour, p.Duration())
+	p, _ = ParsePeriod("As of March 31, 2023")
+	assertEqual(t, time.Duration(0), p.Duration())
+}
+
+func TestPeriods(t *testing.T) {
+	ts, err := NewFromString(`<table>
+		<tr><th></th><th colspan="2">Three Months Ended September 30,</th><th>As of December 31,</th></tr>
+		<tr><th></th><th>2023</th><th>2022</th><th>2022</th></tr>
+		<tr><td>Revenue</td><td>1,200</td><td>1,100</td><td>900</td></tr>
+	</table>`)
+	assertNoError(t, err)
+	periods := ts[0].Periods()
+	assertEqual(t, 4, len(periods))
+	assertEqual(t, (*Period)(nil), periods[0])
+	assertEqual(t, date(2023, 7, 1), periods[1].Start)
+	assertEqual(t, date(2022, 9, 30), periods[2].End)
+	assertEqual(t, "Three Months Ended September 30, 2022", periods[2].Text)
+	assertEqual(t, true, periods[3].Instant)
+	assertEqual(t, date(2022, 12, 31), periods[3].End)
+
+	p, ok := ParsePeriodPath([]string{"Year Ended December 31,", "2023", "Amount"})
+	assertEqual(t, true, ok)
+	assertEqual(t, 12, p.Months)
+}
@@ -0,0 +1,346 @@
+package htmltable
+
+import (
+	"strings"
+	"unicode"
+)
+
+// RenderOption configures Table.Markdown and Table.Text
+type RenderOption func(*renderOptions)
+
+type renderOptions struct {
+	meta       *TableMeta
+	ascii      bool
+	headerRows int // -1 to guess
+}
+
+// MergeSpans renders cells spanning several rows or columns once, using the Cells of m,
+// rather than repeating their value at each position they cover.
+// Markdown has no spans, so there the positions covered are left empty.
+func MergeSpans(m *TableMeta) RenderOption {
+	return func(o *renderOptions) {
+		o.meta = m
+	}
+}
+
+// ASCII draws the borders of Table.Text with +, - and | rather than box-drawing characters
+func ASCII() RenderOption {
+	return func(o *renderOptions) {
+		o.ascii = true
+	}
+}
+
+// RenderHeaderRows sets the number of header rows, which are otherwise guessed as for HeaderRows,
+// followed by any rows of header cells when rendering with MergeSpans
+func RenderHeaderRows(n int) RenderOption {
+	return func(o *renderOptions) {
+		o.headerRows = n
+	}
+}
+
+func newRenderOptions(t Table, opts []RenderOption) *renderOptions {
+	o := &renderOptions{headerRows: -1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.headerRows < 0 {
+		o.headerRows = lookupHeaderRows(t)
+		// rows of <th> cells are header rows too, even when they hold numbers such as years
+		for o.meta != nil && o.headerRows < len(t) && o.headerRows < len(o.meta.Cells) && allHeaderCells(o.meta.Cells[o.headerRows]) {
+			o.headerRows++
+		}
+	}
+	if o.headerRows > len(t) {
+		o.headerRows = len(t)
+	}
+	return o
+}
+
+// allHeaderCells reports whether the cells of a row are all header cells
+func allHeaderCells(cells []*Cell) bool {
+	for _, cell := range cells {
+		if cell == nil || !cell.Header {
+			return false
+		}
+	}
+	return len(cells) > 0
+}
+
+// segment is a run of columns of a rendered row holding a single value
+type segment struct {
+	col  int
+	span int
+	text string
+}
+
+// segments splits each row into the values to render, merging the columns covered by the same cell
+// when the options have a TableMeta, and padding rows to the width of the table
+func (t Table) segments(o *renderOptions) [][]segment {
+	width := columnCount(t)
+	rows := make([][]segment, len(t))
+	for r, row := range t {
+		for c := 0; c < width; c++ {
+			text := ""
+			if c < len(row) {
+				text = row[c]
+			}
+			if cell := o.cell(r, c); cell != nil {
+				if cell.Col != c && len(rows[r]) > 0 {
+					rows[r][len(rows[r])-1].span++
+					continue
+				}
+				if cell.Row != r {
+					text = ""
+				}
+			}
+			rows[r] = append(rows[r], segment{col: c, span: 1, text: text})
+		}
+	}
+	return rows
+}
+
+// cell returns the cell at row r and column c when rendering with MergeSpans, or nil
+func (o *renderOptions) cell(r, c int) *Cell {
+	if o.meta == nil || r >= len(o.meta.Cells) || c >= len(o.meta.Cells[r]) {
+		return nil
+	}
+	return o.meta.Cells[r][c]
+}
+
+// rightAligned reports for each column whether most of its values below the header are numbers
+func (t Table) rightAligned(headerRows int) []bool {
+	width := columnCount(t)
+	aligned := make([]bool, width)
+	for col := 0; col < width; col++ {
+		numbers, values := 0, 0
+		for _, row := range t[headerRows:] {
+			if col >= len(row) || strings.TrimSpace(row[col]) == "" {
+				continue
+			}
+			values++
+			if _, ok := ParseNumber(row[col]); ok {
+				numbers++
+			}
+		}
+		aligned[col] = values > 0 && numbers*2 > values
+	}
+	return aligned
+}
+
+// Markdown renders the table as a GitHub-flavoured Markdown pipe table.
+//
+// The header rows are flattened into the single header row Markdown allows, numeric columns are right-aligned,
+// pipes and backslashes are escaped and line breaks become <br>.
+func (t Table) Markdown(opts ...RenderOption) string {
+	o := newRenderOptions(t, opts)
+	width := columnCount(t)
+	if width == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	writeRow := func(values []string) {
+		sb.WriteByte('|')
+		for col := 0; col < width; col++ {
+			value := ""
+			if col < len(values) {
+				value = markdownEscape(values[col])
+			}
+			sb.WriteString(" " + value + " |")
+		}
+		sb.WriteByte('\n')
+	}
+	writeRow(t.Header(HeaderRows(o.headerRows)))
+	sb.WriteByte('|')
+	for _, right := range t.rightAligned(o.headerRows) {
+		if right {
+			sb.WriteString(" ---: |")
+		} else {
+			sb.WriteString(" --- |")
+		}
+	}
+	sb.WriteByte('\n')
+	body := t.segments(o)[o.headerRows:]
+	for _, row := range body {
+		values := make([]string, width)
+		for _, seg := range row {
+			values[seg.col] = seg.text
+		}
+		writeRow(values)
+	}
+	return sb.String()
+}
+
+var markdownReplacer = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func markdownEscape(s string) string {
+	return markdownReplacer.Replace(strings.TrimSpace(s))
+}
+
+// boxChars are the characters borders are drawn with
+type boxChars struct {
+	horizontal, vertical string
+	// joints are indexed by whether a vertical line leaves upwards (1) and downwards (2)
+	joints [4]string
+	// corners are the left and right ends of the top, middle and bottom lines
+	left, right [3]string
+}
+
+var (
+	boxDrawing = boxChars{
+		horizontal: "─", vertical: "│",
+		joints: [4]string{"─", "┴", "┬", "┼"},
+		left:   [3]string{"┌", "├", "└"},
+		right:  [3]string{"┐", "┤", "┘"},
+	}
+	asciiDrawing = boxChars{
+		horizontal: "-", vertical: "|",
+		joints: [4]string{"-", "+", "+", "+"},
+		left:   [3]string{"+", "+", "+"},
+		right:  [3]string{"+", "+", "+"},
+	}
+)
+
+// Text renders the table as aligned plain text within borders, with a line below the header rows.
+// Column widths account for East Asian wide characters, numeric columns are right-aligned,
+// and values with line breaks take several lines.
+func (t Table) Text(opts ...RenderOption) string {
+	o := newRenderOptions(t, opts)
+	width := columnCount(t)
+	if width == 0 {
+		return ""
+	}
+	box := boxDrawing
+	if o.ascii {
+		box = asciiDrawing
+	}
+	rows := t.segments(o)
+	aligned := t.rightAligned(o.headerRows)
+
+	// single columns set the widths, then spans widen their last column when they need more room
+	widths := make([]int, width)
+	for _, row := range rows {
+		for _, seg := range row {
+			if w := textWidth(seg.text); seg.span == 1 && w > widths[seg.col] {
+				widths[seg.col] = w
+			}
+		}
+	}
+	for _, row := range rows {
+		for _, seg := range row {
+			if seg.span == 1 {
+				continue
+			}
+			if need := textWidth(seg.text) - spanWidth(widths, seg); need > 0 {
+				widths[seg.col+seg.span-1] += need
+			}
+		}
+	}
+
+	var sb strings.Builder
+	line := func(pos int, above, below []segment) {
+		sb.WriteString(box.left[pos])
+		for col := 0; col < width; col++ {
+			sb.WriteString(strings.Repeat(box.horizontal, widths[col]+2))
+			if col == width-1 {
+				break
+			}
+			joint := 0
+			if startsAt(above, col+1) {
+				joint |= 1
+			}
+			if startsAt(below, col+1) {
+				joint |= 2
+			}
+			sb.WriteString(box.joints[joint])
+		}
+		sb.WriteString(box.right[pos] + "\n")
+	}
+
+	line(0, nil, rows[0])
+	for r, row := range rows {
+		if r == o.headerRows && r > 0 {
+			line(1, rows[r-1], row)
+		}
+		lines := 1
+		for _, seg := range row {
+			if n := strings.Count(seg.text, "\n") + 1; n > lines {
+				lines = n
+			}
+		}
+		for i := 0; i < lines; i++ {
+			sb.WriteString(box.vertical)
+			for _, seg := range row {
+				text := ""
+				if parts := strings.Split(seg.text, "\n"); i < len(parts) {
+					text = strings.TrimSpace(parts[i])
+				}
+				pad := strings.Repeat(" ", spanWidth(widths, seg)-textWidth(text))
+				if aligned[seg.col] && r >= o.headerRows {
+					sb.WriteString(" " + pad + text + " ")
+				} else {
+					sb.WriteString(" " + text + pad + " ")
+				}
+				sb.WriteString(box.vertical)
+			}
+			sb.WriteByte('\n')
+		}
+	}
+	line(2, rows[len(rows)-1], nil)
+	return sb.String()
+}
+
+// spanWidth is the width available to a segment, including the borders between the columns it spans
+func spanWidth(widths []int, seg segment) int {
+	w := 3 * (seg.span - 1)
+	for col := seg.col; col < seg.col+seg.span; col++ {
+		w += widths[col]
+	}
+	return w
+}
+
+// startsAt reports whether a segment of row starts at col, so that a border is drawn on its left
+func startsAt(row []segment, col int) bool {
+	for _, seg := range row {
+		if seg.col == col {
+			return true
+		}
+	}
+	return false
+}
+
+// textWidth is the number of terminal columns taken by the widest line of s
+func textWidth(s string) int {
+	widest := 0
+	for _, line := range strings.Split(s, "\n") {
+		w := 0
+		for _, r := range strings.TrimSpace(line) {
+			w += runeWidth(r)
+		}
+		if w > widest {
+			widest = w
+		}
+	}
+	return widest
+}
+
+// runeWidth is the number of terminal columns taken by r: 2 for East Asian wide and fullwidth characters,
+// 0 for combining marks and zero-width characters and 1 otherwise
+func runeWidth(r rune) int {
+	switch {
+	case r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\ufeff',
+		unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r):
+		return 0
+	case r >= 0x1100 && r <= 0x115f,
+		r >= 0x2e80 && r <= 0xa4cf && r != 0x303f,
+		r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff,
+		r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0xff00 && r <= 0xff60,
+		r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f300 && r <= 0x1f64f,
+		r >= 0x1f900 && r <= 0x1f9ff,
+		r >= 0x20000 && r <= 0x3fffd:
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,56 @@
+package htmltable
+
+import (
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	p, err := ParseString(`<table>
+		<tr><th>Item</th><th>Amount</th></tr>
+		<tr><td rowspan="2">a|b</td><td>1,200</td></tr>
+		<tr><td>(3)</td></tr>
+		<tr><td>line<br>break</td><td>—</td></tr>
+	</table>`, WithTextMode(TextBlock))
+	assertNoError(t, err)
+	table := p.Tables[0]
+	assertEqual(t, "| Item | Amount |\n"+
+		"| --- | ---: |\n"+
+		"| a\\|b | 1,200 |\n"+
+		"| a\\|b | (3) |\n"+
+		"| line<br>break | — |\n", table.Markdown())
+	assertEqual(t, "| Item | Amount |\n"+
+		"| --- | ---: |\n"+
+		"| a\\|b | 1,200 |\n"+
+		"|  | (3) |\n"+
+		"| line<br>break | — |\n", table.Markdown(MergeSpans(p.Meta[0])))
+	assertEqual(t, "", Table{}.Markdown())
+}
+
+func TestText(t *testing.T) {
+	p, err := ParseString(testTable2)
+	assertNoError(t, err)
+	table := p.Tables[0]
+	assertEqual(t, `┌───────────────┬───────────────────────────┬────────────────────────┬───────────────────────────────────┐
+│ Date          │ Added                     │ Removed                │ Reason                            │
+│               │ Ticker │ Security         │ Ticker │ Security      │                                   │
+├───────────────┼────────┼──────────────────┼────────┼───────────────┼───────────────────────────────────┤
+│ June 21, 2022 │ KDP    │ Keurig Dr Pepper │ UA/UAA │ Under Armour  │ Market capitalization change. [4] │
+│ June 21, 2022 │ ON     │ ON Semiconductor │ IPGP   │ IPG Photonics │ Market capitalization change. [4] │
+└───────────────┴────────┴──────────────────┴────────┴───────────────┴───────────────────────────────────┘
+`, table.Text(MergeSpans(p.Meta[0])))
+
+	wide := Table{{"名前", "Amount"}, {"東京", "1,200"}, {"Osaka\nKansai", "(3)"}, {"x"}}
+	assertEqual(t, `+--------+--------+
+| 名前   | Amount |
++--------+--------+
+| 東京   |  1,200 |
+| Osaka  |    (3) |
+| Kansai |        |
+| x      |        |
++--------+--------+
+`, wide.Text(ASCII()))
+	assertEqual(t, `┌───┐
+│ x │
+└───┘
+`, Table{{"x"}}.Text(RenderHeaderRows(0)))
+}
@@ -0,0 +1,155 @@
+package htmltable
+
+import "strings"
+
+// spaceWidth is the indentation, in points, given to each leading fixed-width space of a label
+const spaceWidth = 3.0
+
+// RowTree is the hierarchy of the rows of a table, derived from the indentation of their labels
+// in the first column, e.g. "Sales and marketing" indented below "Operating expenses:".
+type RowTree struct {
+	// Roots are the top level rows, in table order
+	Roots []*RowNode
+	// Nodes holds the node of each row of the table, at the same index.
+	// Rows without a label have no node.
+	Nodes []*RowNode
+}
+
+// RowNode is a single labelled row within a RowTree
+type RowNode struct {
+	// Row is the index of the row within the table
+	Row int
+	// Label is the text of the first column, without any trailing colon
+	Label string
+	// Indent is the indentation of the label in points
+	Indent   float64
+	Parent   *RowNode
+	Children []*RowNode
+	// section is set for labels ending in a colon, which head the rows that follow
+	section bool
+}
+
+// NewRowTree builds the RowTree of table t.
+//
+// Indentation is taken from the styles and leading spaces of the labels in m, which is optional.
+// Regardless of indentation, a label ending in a colon, such as "Operating expenses:",
+// becomes the parent of the rows after it. A row whose label starts with "Total" is the last child
+// of the section it closes, preferring an open row it names, e.g. "Total operating expenses".
+func NewRowTree(t *Table, m *TableMeta) *RowTree {
+	tree := &RowTree{
+		Nodes: make([]*RowNode, len(*t)),
+	}
+	var stack []*RowNode
+	for i, row := range *t {
+		if len(row) == 0 {
+			continue
+		}
+		label := strings.TrimSpace(row[0])
+		if label == "" {
+			continue
+		}
+		node := &RowNode{
+			Row:     i,
+			Label:   strings.TrimSpace(strings.TrimSuffix(label, ":")),
+			Indent:  labelIndent(m, i),
+			section: strings.HasSuffix(label, ":"),
+		}
+		tree.Nodes[i] = node
+
+		total := isTotalLabel(node.Label)
+		if total {
+			// a total naming an open row, e.g. "Total operating expenses", belongs to that row
+			for j := len(stack) - 1; j >= 0; j-- {
+				if strings.Contains(strings.ToLower(node.Label), strings.ToLower(stack[j].Label)) {
+					stack = stack[:j+1]
+					break
+				}
+			}
+		}
+		// close rows that cannot be the parent: those indented deeper, and siblings at the same indent
+		// unless they head a section, or have children the row totals
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			if top.Indent > node.Indent || top.Indent == node.Indent && (!top.heads(total) || node.section) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			break
+		}
+		if total {
+			// totals are often indented deeper than the rows they sum, so close those too
+			for len(stack) > 0 {
+				top := stack[len(stack)-1]
+				if len(top.Children) > 0 || top.section {
+					break
+				}
+				stack = stack[:len(stack)-1]
+			}
+		}
+
+		if len(stack) > 0 {
+			parent := stack[len(stack)-1]
+			node.Parent = parent
+			parent.Children = append(parent.Children, node)
+		} else {
+			tree.Roots = append(tree.Roots, node)
+		}
+
+		if total {
+			// a total ends its section
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		stack = append(stack, node)
+	}
+	return tree
+}
+
+// heads reports whether the node is the parent of a following row at the same indent
+func (n *RowNode) heads(total bool) bool {
+	if total && len(n.Children) > 0 {
+		return true
+	}
+	// a section whose children are indented is closed by a row at its own indent
+	return n.section && (len(n.Children) == 0 || n.Children[0].Indent == n.Indent)
+}
+
+// labelIndent returns the indentation of the label of row i in points, or 0 if unknown
+func labelIndent(m *TableMeta, i int) float64 {
+	if m == nil || i >= len(m.Cells) || len(m.Cells[i]) == 0 {
+		return 0
+	}
+	style := m.Cells[i][0].Style
+	return style.Indent() + float64(style.LeadingSpaces)*spaceWidth
+}
+
+// isTotalLabel reports whether a row label describes a total
+func isTotalLabel(label string) bool {
+	return strings.HasPrefix(strings.ToLower(label), "total")
+}
+
+// Path returns the labels from the root of the tree down to this node
+func (n *RowNode) Path() []string {
+	var path []string
+	for ; n != nil; n = n.Parent {
+		path = append([]string{n.Label}, path...)
+	}
+	return path
+}
+
+// QualifiedLabel returns the path of the node joined by " > ",
+// e.g. "Operating expenses > Sales and marketing"
+func (n *RowNode) QualifiedLabel() string {
+	return strings.Join(n.Path(), " > ")
+}
+
+// Depth is the number of ancestors of the node
+func (n *RowNode) Depth() int {
+	depth := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+	return depth
+}
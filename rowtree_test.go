@@ -0,0 +1,62 @@
+package htmltable
+
+import "testing"
+
+func TestRowTree(t *testing.T) {
+	p, err := ParseString(testTable3)
+	assertNoError(t, err)
+	tree := NewRowTree(p.Tables[0], p.Meta[0])
+	assertEqual(t, 4, len(tree.Roots))
+	assertEqual(t, (*RowNode)(nil), tree.Nodes[0])
+	assertEqual(t, "Fair value and other adjustments > Unrealized loss, charge-offs, and other adjustments, net", tree.Nodes[6].QualifiedLabel())
+	assertEqual(t, "Fair value and other adjustments > Total fair value and other adjustments, net (1)", tree.Nodes[8].QualifiedLabel())
+	assertEqual(t, 3, len(tree.Nodes[5].Children))
+	assertEqual(t, 0, tree.Nodes[10].Depth())
+}
+
+func TestRowTreeSections(t *testing.T) {
+	p, err := ParseString(testTableIncomeStatement)
+	assertNoError(t, err)
+	tree := NewRowTree(p.Tables[0], p.Meta[0])
+	var labels []string
+	for _, n := range tree.Nodes {
+		if n != nil {
+			labels = append(labels, n.QualifiedLabel())
+		}
+	}
+	assertEqual(t, []string{
+		"Revenue",
+		"Revenue > Product",
+		"Revenue > Services",
+		"Operating expenses",
+		"Operating expenses > Sales and marketing",
+		"Operating expenses > Technology",
+		"Operating expenses > Technology > Cloud",
+		"Operating expenses > Total operating expenses",
+		"Other income",
+		"Other income > Interest",
+		"Net loss",
+	}, labels)
+
+	// without styles, only colon sections are recognised
+	flat := NewRowTree(p.Tables[0], nil)
+	assertEqual(t, "Operating expenses > Technology", flat.Nodes[6].QualifiedLabel())
+	assertEqual(t, "Operating expenses > Cloud", flat.Nodes[7].QualifiedLabel())
+	assertEqual(t, "Revenue", flat.Nodes[1].QualifiedLabel())
+	assertEqual(t, []string{"Other income", "Interest"}, flat.Nodes[10].Path())
+}
+
+const testTableIncomeStatement = `<table>
+	<tr><td></td><td>2023</td><td>2022</td></tr>
+	<tr><td>Revenue</td><td>300</td><td>250</td></tr>
+	<tr><td style="padding-left: 13pt">Product</td><td>200</td><td>150</td></tr>
+	<tr><td style="padding-left: 13pt">Services</td><td>100</td><td>100</td></tr>
+	<tr><td>Operating expenses:</td><td></td><td></td></tr>
+	<tr><td style="padding-left: 13pt">Sales and marketing</td><td>50</td><td>40</td></tr>
+	<tr><td>&nbsp;&nbsp;&nbsp;&nbsp;<span>Technology</span></td><td>70</td><td>60</td></tr>
+	<tr><td style="padding-left: 13pt"><div style="padding-left: 10pt">Cloud</div></td><td>20</td><td>10</td></tr>
+	<tr><td style="padding-left: 26pt">Total operating expenses</td><td>120</td><td>100</td></tr>
+	<tr><td>Other income:</td><td></td><td></td></tr>
+	<tr><td style="text-indent: 1em">Interest</td><td>5</td><td>4</td></tr>
+	<tr><td style="font-weight: bold; border-top: 1px solid">Net loss</td><td>(185)</td><td>(154)</td></tr>
+</table>`
@@ -0,0 +1,102 @@
+package htmltable
+
+import (
+	"strings"
+)
+
+// ColumnType is the type of the values of a column
+type ColumnType int
+
+// Column types inferred by Table.Schema
+const (
+	TypeString ColumnType = iota
+	TypeInt64
+	TypeFloat64
+	TypeDate
+	TypeBool
+)
+
+func (c ColumnType) String() string {
+	switch c {
+	case TypeString:
+		return "string"
+	case TypeInt64:
+		return "int64"
+	case TypeFloat64:
+		return "float64"
+	case TypeDate:
+		return "date"
+	case TypeBool:
+		return "bool"
+	}
+	return "unknown"
+}
+
+// Field is a column of a Schema
+type Field struct {
+	// Name is the flattened header of the column, made unique within the table
+	Name string
+	// Col is the index of the column in the Table
+	Col  int
+	Type ColumnType
+	// Nullable is set when some of the values of the column are blank
+	Nullable bool
+}
+
+// Schema describes the columns of the rows of a table below its header
+type Schema struct {
+	Fields []Field
+	// HeaderRows is the number of rows above the values described
+	HeaderRows int
+}
+
+// parseStrictBool parses the words and checkboxes used for booleans, but not the digits, letters and dashes
+// parseBool accepts, which are as likely to be numbers or text
+func parseStrictBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "✓", "✔", "☑", "☒":
+		return true, true
+	case "false", "no", "☐", "✗", "✘":
+		return false, true
+	}
+	return false, false
+}
+
+// columnType returns the ColumnType holding values of kind
+func columnType(kind ValueKind) ColumnType {
+	switch kind {
+	case ValueInteger:
+		return TypeInt64
+	case ValueDecimal, ValuePercent, ValueCurrency:
+		return TypeFloat64
+	case ValueDate:
+		return TypeDate
+	case ValueBoolean:
+		return TypeBool
+	}
+	return TypeString
+}
+
+// Schema infers the type of each column from the values below the header rows, which are guessed
+// unless given by HeaderRows. Columns are classified as by InferSchema, except that every value must fit:
+// a column is int64 when all its values are whole numbers as read by ParseNumber, float64 when they are
+// all numbers, date when they are all dates, bool when they are all true/false, yes/no or checkboxes,
+// and string otherwise. Blank values and placeholders such as dashes and "n/a" are ignored,
+// but make the column nullable.
+func (t Table) Schema(opts ...LookupOption) Schema {
+	l := newLookup(t, opts)
+	width := columnCount(t)
+	keys := jsonKeys(t.Header(HeaderRows(l.headerRows)), width)
+	schema := Schema{HeaderRows: l.headerRows}
+	for col := 0; col < width; col++ {
+		c := countColumn(t, l.headerRows, col)
+		kind, _ := c.kind(allValues)
+		schema.Fields = append(schema.Fields, Field{
+			Name:     keys[col],
+			Col:      col,
+			Type:     columnType(kind),
+			Nullable: c.blank > 0,
+		})
+	}
+	return schema
+}
@@ -0,0 +1,49 @@
+package htmltable
+
+import (
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	table := Table{
+		{"Name", "Shares", "Price", "Listed", "Active", "Note", "Empty"},
+		{"A", "1,200", "$ 10.50", "2023-01-31", "Yes", "1", ""},
+		{"B", "", "12", "Jan 5, 2022", "no", "x"},
+		{"C", "(30)", "12.5%", "", "", "—"},
+	}
+	schema := table.Schema()
+	assertEqual(t, 1, schema.HeaderRows)
+	assertEqual(t, []Field{
+		{Name: "Name", Col: 0, Type: TypeString},
+		{Name: "Shares", Col: 1, Type: TypeInt64, Nullable: true},
+		{Name: "Price", Col: 2, Type: TypeFloat64},
+		{Name: "Listed", Col: 3, Type: TypeDate, Nullable: true},
+		{Name: "Active", Col: 4, Type: TypeBool, Nullable: true},
+		{Name: "Note", Col: 5, Type: TypeString, Nullable: true},
+		{Name: "Empty", Col: 6, Type: TypeString, Nullable: true},
+	}, schema.Fields)
+	assertEqual(t, "date", TypeDate.String())
+}
+
+func TestSchemaMatchesInferSchema(t *testing.T) {
+	table := Table{
+		{"Audited", "Change", "Count"},
+		{"☒", "12.5%", "1"},
+		{"☐", "(2.0%)", "2"},
+		{"yes", "\u2014", "three"},
+	}
+	schema := table.Schema()
+	reports := InferSchema(&table)
+	// the same classifier, requiring all values to fit for Schema but most of them for InferSchema
+	assertEqual(t, TypeBool, schema.Fields[0].Type)
+	assertEqual(t, ValueBoolean, reports[0].Kind)
+	assertEqual(t, TypeFloat64, schema.Fields[1].Type)
+	assertEqual(t, ValuePercent, reports[1].Kind)
+	assertEqual(t, TypeString, schema.Fields[2].Type)
+	assertEqual(t, ValueInteger, reports[2].Kind)
+	for i, r := range reports {
+		if r.Unparseable == 0 {
+			assertEqual(t, columnType(r.Kind), schema.Fields[i].Type)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package htmltable
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// continuedRegexp matches a whole text ending in the "(continued)" note of a table split across pages,
+// e.g. "(Continued)", "Loans (continued)" or "Loans — continued", but not prose using the word
+var continuedRegexp = regexp.MustCompile(`(?i)^(?:.{0,100}?(?:\(\s*continued\s*\)|[—–-]\s*continued)|continued)[\s.:]*$`)
+
+// WithStitching merges tables split across page breaks into one.
+//
+// A table continues the one before it when it has the same number of columns and either starts by
+// repeating its header rows, or is marked "(continued)" in its caption, its first row or the block
+// of text just before it. Repeated header rows need evidence of a split as well: a page break styled
+// between the tables, or no text between them. Repeated header rows and "(continued)" rows are removed when merging.
+func WithStitching() Option {
+	return func(p *Parser) {
+		p.stitch = true
+	}
+}
+
+// stitchTables merges continuation tables into the table they continue, keeping Tables and Meta aligned
+func (p *Parser) stitchTables() {
+	if len(p.Tables) == 0 {
+		return
+	}
+	tables := []*Table{p.Tables[0]}
+	meta := []*TableMeta{p.Meta[0]}
+	for i := 1; i < len(p.Tables); i++ {
+		last := len(tables) - 1
+		skip, ok := continuation(*tables[last], *p.Tables[i], p.Meta[i])
+		if !ok {
+			tables = append(tables, p.Tables[i])
+			meta = append(meta, p.Meta[i])
+			continue
+		}
+		tables[last], meta[last] = mergeTables(tables[last], meta[last], p.Tables[i], p.Meta[i], skip)
+	}
+	p.Tables = tables
+	p.Meta = meta
+}
+
+// continuation reports whether table b, described by bm, continues table a,
+// returning the number of leading rows of b to drop as repeated headers or "(continued)" notes
+func continuation(a, b Table, bm *TableMeta) (int, bool) {
+	if len(a) == 0 || len(b) == 0 || columnCount(a) != columnCount(b) {
+		return 0, false
+	}
+	skip := 0
+	blocks := strings.Split(bm.Preceding, "\n")
+	continued := continuedRegexp.MatchString(strings.TrimSpace(bm.Caption)) ||
+		continuedRegexp.MatchString(strings.TrimSpace(blocks[len(blocks)-1]))
+	// a first row holding only a "(continued)" title
+	if distinct := distinctValues(b[0]); len(distinct) == 1 && continuedRegexp.MatchString(distinct[0]) {
+		continued = true
+		skip = 1
+	}
+	// rows repeating the header of a, which is found the same way in both tables
+	header := headerRowCount(a)
+	repeated := 0
+	for repeated < header && skip+repeated < len(b) && sameRow(a[repeated], b[skip+repeated]) {
+		repeated++
+	}
+	split := continued || bm.pageBreak || strings.TrimSpace(bm.Preceding) == ""
+	if repeated == 0 && !continued || !split {
+		return 0, false
+	}
+	return skip + repeated, true
+}
+
+// pageBreakBefore reports whether a page break is styled on table node n, its ancestors,
+// or anything between it and the table before it
+func (p *Parser) pageBreakBefore(n *html.Node) bool {
+	found, stop := false, false
+	var walk func(n *html.Node)
+	// walk visits n and its descendants backwards, stopping at the previous table
+	walk = func(n *html.Node) {
+		if found || stop || n.Type != html.ElementNode || p.isHidden(n) {
+			return
+		}
+		if isPageBreak(p.styles.style(n)) {
+			found = true
+			return
+		}
+		if p.kindOf(n) == kindTable {
+			stop = true
+			return
+		}
+		for c := n.LastChild; c != nil; c = c.PrevSibling {
+			walk(c)
+		}
+	}
+	for cur := n; cur != nil && !found && !stop; cur = cur.Parent {
+		if cur.Type == html.ElementNode && isPageBreak(p.styles.style(cur)) {
+			return true
+		}
+		for s := cur.PrevSibling; s != nil && !found && !stop; s = s.PrevSibling {
+			walk(s)
+		}
+	}
+	return found
+}
+
+// isPageBreak reports whether a style forces a page break before or after its element
+func isPageBreak(style map[string]string) bool {
+	for _, key := range []string{"page-break-before", "page-break-after", "break-before", "break-after"} {
+		switch strings.ToLower(style[key]) {
+		case "always", "page", "left", "right":
+			return true
+		}
+	}
+	return false
+}
+
+// mergeTables returns table a with the rows of b after the first skip appended, along with merged metadata
+func mergeTables(a *Table, am *TableMeta, b *Table, bm *TableMeta, skip int) (*Table, *TableMeta) {
+	offset := len(*a) - skip
+	merged := append(append(Table{}, *a...), (*b)[skip:]...)
+	m := *am
+	m.Cells = append(append([][]*Cell{}, am.Cells...), bm.Cells[skip:]...)
+	// cells spanning several positions appear more than once, but must only be moved once
+	moved := map[*Cell]bool{}
+	for _, row := range bm.Cells[skip:] {
+		for _, cell := range row {
+			if !moved[cell] {
+				cell.Row += offset
+				moved[cell] = true
+			}
+		}
+	}
+	for _, hidden := range bm.HiddenRows {
+		if hidden >= skip {
+			m.HiddenRows = append(m.HiddenRows, hidden+offset)
+		}
+	}
+	if len(m.Parts) == 0 {
+		m.Parts = []int{am.Index}
+	}
+	m.Parts = append(m.Parts, bm.Index)
+	return &merged, &m
+}
+
+// columnCount returns the length of the longest row of t
+func columnCount(t Table) int {
+	cols := 0
+	for _, row := range t {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	return cols
+}
+
+// sameRow reports whether two rows hold the same text
+func sameRow(a, b []string) bool {
+	if len(a) != len(b) || !hasText(a) {
+		return false
+	}
+	for i := range a {
+		if strings.TrimSpace(a[i]) != strings.TrimSpace(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// distinctValues returns the distinct non-blank values of a row, in order
+func distinctValues(row []string) []string {
+	var values []string
+	seen := map[string]bool{}
+	for _, v := range row {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,104 @@
+package htmltable
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStitching(t *testing.T) {
+	p, err := ParseString(testTableSplit, WithStitching())
+	assertNoError(t, err)
+	assertEqual(t, 2, len(p.Tables))
+	assertEqual(t, *p.Tables[0], Table{
+		{"Loan", "Amount", "Amount"},
+		{"", "2023", "2022"},
+		{"A", "1", "2"},
+		{"B", "3", "4"},
+		{"C", "5", "6"},
+		{"D", "7", "8"},
+	})
+	assertEqual(t, []int{0, 1, 2}, p.Meta[0].Parts)
+	assertEqual(t, 6, len(p.Meta[0].Cells))
+	assertEqual(t, 5, p.Meta[0].Cells[5][0].Row)
+	assertEqual(t, "D", p.Meta[0].Cells[5][0].Value)
+	assertEqual(t, 3, p.Meta[1].Index)
+
+	p, err = ParseString(testTableSplit)
+	assertNoError(t, err)
+	assertEqual(t, 4, len(p.Tables))
+}
+
+func TestStitchingProse(t *testing.T) {
+	p, err := ParseString(`<table>
+		<tr><td>A</td><td>1</td></tr>
+	</table>
+	<p>We continued to invest in our platform.</p>
+	<p>Since then, our growth has continued, as shown below.</p>
+	<table>
+		<tr><td>Other</td><td>9</td></tr>
+	</table>`, WithStitching())
+	assertNoError(t, err)
+	assertEqual(t, 2, len(p.Tables))
+
+	// only the block just before the table counts
+	p, err = ParseString(`<table><tr><td>A</td><td>1</td></tr></table>
+	<p>Loans (continued)</p><p>Other loans</p>
+	<table><tr><td>B</td><td>2</td></tr></table>`, WithStitching())
+	assertNoError(t, err)
+	assertEqual(t, 2, len(p.Tables))
+}
+
+func TestStitchingNeedsSplit(t *testing.T) {
+	const statement = `<table>
+		<tr><th></th><th>2023</th><th>2022</th></tr>
+		<tr><td>%s</td><td>1</td><td>2</td></tr>
+	</table>`
+	two := func(between string) string {
+		return strings.Replace(statement, "%s", "Revenue", 1) + between + strings.Replace(statement, "%s", "Cash", 1)
+	}
+	// consecutive statements sharing a header are separate
+	p, err := ParseString(two(`<h2>Balance Sheets</h2>`), WithStitching())
+	assertNoError(t, err)
+	assertEqual(t, 2, len(p.Tables))
+
+	for _, between := range []string{"", `<div style="page-break-before: always"></div><p>12</p>`} {
+		p, err = ParseString(two(between), WithStitching())
+		assertNoError(t, err)
+		assertEqual(t, 1, len(p.Tables))
+		assertEqual(t, 3, len(*p.Tables[0]))
+	}
+}
+
+func TestContinuedNote(t *testing.T) {
+	for _, s := range []string{"(Continued)", "Loans (continued)", "Loans — Continued", "Loans - continued:", "continued", "CONTINUED."} {
+		assertEqual(t, true, continuedRegexp.MatchString(s))
+	}
+	for _, s := range []string{"We continued to invest", "Growth continued", "Continued operations", "(continued) growth of revenue"} {
+		assertEqual(t, false, continuedRegexp.MatchString(s))
+	}
+}
+
+const testTableSplit = `<body>
+<table>
+	<tr><th>Loan</th><th colspan="2">Amount</th></tr>
+	<tr><th></th><th>2023</th><th>2022</th></tr>
+	<tr><td>A</td><td>1</td><td>2</td></tr>
+	<tr><td>B</td><td>3</td><td>4</td></tr>
+</table>
+<div style="page-break-after: always"></div>
+<p>12</p>
+<table>
+	<tr><th>Loan</th><th colspan="2">Amount</th></tr>
+	<tr><th></th><th>2023</th><th>2022</th></tr>
+	<tr><td>C</td><td>5</td><td>6</td></tr>
+</table>
+<div style="page-break-after: always"></div>
+<p>Loans (continued)</p>
+<table>
+	<tr><td>D</td><td>7</td><td>8</td></tr>
+</table>
+<table>
+	<tr><td>Other</td><td>9</td></tr>
+	<tr><td>E</td><td>10</td></tr>
+</table>
+</body>`
@@ -0,0 +1,382 @@
+package htmltable
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// CellStyle holds the formatting of a cell, as far as it helps to interpret the content.
+//
+// Inherited properties (font-weight, font-style, text-align, text-indent) are taken from the element
+// nearest to the first text of the cell that declares them, up to the enclosing table.
+// Lengths are in points.
+type CellStyle struct {
+	// FontWeight is the numeric weight of the text, 400 being normal and 700 bold
+	FontWeight int
+	// FontStyle is "normal", "italic" or "oblique"
+	FontStyle string
+	// TextAlign is the alignment of the text, or "" when not declared
+	TextAlign string
+	// PaddingLeft is the left padding of the cell plus any left padding and margins
+	// of the elements between the cell and its first text
+	PaddingLeft float64
+	TextIndent  float64
+	// LeadingSpaces counts the non-breaking and other fixed-width spaces before the first text of the cell,
+	// which are often used for indentation
+	LeadingSpaces int
+	// BorderTop and BorderBottom are the borders of the cell, or of its row when the cell has none
+	BorderTop    Border
+	BorderBottom Border
+	// Background is the background color of the cell or its row as declared, e.g. "rgb(255, 255, 255)"
+	Background string
+}
+
+// Border is a single side of a css border
+type Border struct {
+	Width float64
+	// Style is e.g. "solid" or "double", or "" when there is no border
+	Style string
+	Color string
+}
+
+// Bold reports whether the text of the cell is bold
+func (s CellStyle) Bold() bool {
+	return s.FontWeight >= 600
+}
+
+// Italic reports whether the text of the cell is italic
+func (s CellStyle) Italic() bool {
+	return s.FontStyle == "italic" || s.FontStyle == "oblique"
+}
+
+// Indent is the total indentation of the text of the cell
+func (s CellStyle) Indent() float64 {
+	return s.PaddingLeft + s.TextIndent
+}
+
+// Visible reports whether the border is drawn
+func (b Border) Visible() bool {
+	return b.Style != "" && b.Style != "none" && b.Style != "hidden" && b.Width != 0
+}
+
+// tagFontWeight and tagFontStyle are the default styles browsers give some elements
+var (
+	tagFontWeight = map[string]int{"b": 700, "strong": 700, "th": 700, "h1": 700, "h2": 700, "h3": 700, "h4": 700, "h5": 700, "h6": 700}
+	tagFontStyle  = map[string]string{"i": "italic", "em": "italic", "cite": "italic", "var": "italic", "dfn": "italic"}
+)
+
+// cellStyle resolves the CellStyle of cell node n
+func (p *Parser) cellStyle(n *html.Node) CellStyle {
+	style := CellStyle{
+		FontWeight: 400,
+		FontStyle:  "normal",
+	}
+	style.LeadingSpaces, _ = p.leadingSpaces(n)
+	// path runs from the element holding the first text of the cell up to the cell itself
+	path := []*html.Node{n}
+	if text := p.firstText(n); text != nil {
+		path = nil
+		for e := text.Parent; e != nil && e != n; e = e.Parent {
+			path = append(path, e)
+		}
+		path = append(path, n)
+	}
+	// inherited properties continue beyond the cell up to the table
+	inherited := path
+	for e := n.Parent; e != nil; e = e.Parent {
+		inherited = append(inherited, e)
+		if p.kindOf(e) == kindTable {
+			break
+		}
+	}
+
+	weightSet, fontStyleSet, alignSet, indentSet := false, false, false, false
+	for _, e := range inherited {
+		decls := p.styles.style(e)
+		if !weightSet {
+			if w, ok := parseFontWeight(decls["font-weight"]); ok {
+				style.FontWeight, weightSet = w, true
+			} else if w, ok := tagFontWeight[e.Data]; ok {
+				style.FontWeight, weightSet = w, true
+			}
+		}
+		if !fontStyleSet {
+			if fs := decls["font-style"]; fs != "" && fs != "inherit" {
+				style.FontStyle, fontStyleSet = fs, true
+			} else if fs, ok := tagFontStyle[e.Data]; ok {
+				style.FontStyle, fontStyleSet = fs, true
+			}
+		}
+		if !alignSet {
+			if a := decls["text-align"]; a != "" && a != "inherit" {
+				style.TextAlign, alignSet = a, true
+			} else if a := getAttr(e, "align"); a != "" {
+				style.TextAlign, alignSet = strings.ToLower(a), true
+			}
+		}
+		if !indentSet {
+			if l, ok := parseLength(decls["text-indent"]); ok {
+				style.TextIndent, indentSet = l, true
+			}
+		}
+	}
+
+	for _, e := range path {
+		decls := p.styles.style(e)
+		style.PaddingLeft += boxLeft(decls, "padding")
+		if e != n {
+			style.PaddingLeft += boxLeft(decls, "margin")
+		}
+	}
+
+	// borders and backgrounds belong to the cell, falling back to the row
+	var rowDecls map[string]string
+	for e := n.Parent; e != nil; e = e.Parent {
+		if k := p.kindOf(e); k == kindRow {
+			rowDecls = p.styles.style(e)
+			break
+		} else if k == kindTable {
+			break
+		}
+	}
+	cellDecls := p.styles.style(n)
+	for _, decls := range []map[string]string{cellDecls, rowDecls} {
+		if decls == nil {
+			continue
+		}
+		if style.BorderTop.Style == "" {
+			style.BorderTop = parseBorder(decls, "top")
+		}
+		if style.BorderBottom.Style == "" {
+			style.BorderBottom = parseBorder(decls, "bottom")
+		}
+		if style.Background == "" {
+			style.Background = parseBackground(decls)
+		}
+	}
+	return style
+}
+
+// firstText returns the first visible, non-blank text node below n
+func (p *Parser) firstText(n *html.Node) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			if strings.TrimSpace(c.Data) != "" {
+				return c
+			}
+		case html.ElementNode:
+			if p.isHidden(c) || skippedElements[c.Data] {
+				continue
+			}
+			if _, ok := p.footnoteMarker(c); ok {
+				continue
+			}
+			if found := p.firstText(c); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// leadingSpaces counts the fixed-width spaces before the first text below n, ignoring ordinary whitespace.
+// It also reports whether that text was found, so counting can stop there.
+func (p *Parser) leadingSpaces(n *html.Node) (int, bool) {
+	count := 0
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			for _, r := range c.Data {
+				switch r {
+				case '\u00a0', '\u2002', '\u2003', '\u2007', '\u2009':
+					count++
+				case ' ', '\t', '\n', '\r', '\f':
+				default:
+					return count, true
+				}
+			}
+		case html.ElementNode:
+			if p.isHidden(c) || skippedElements[c.Data] {
+				continue
+			}
+			inner, found := p.leadingSpaces(c)
+			count += inner
+			if found {
+				return count, true
+			}
+		}
+	}
+	return count, false
+}
+
+// parseFontWeight converts a css font-weight to its numeric value
+func parseFontWeight(s string) (int, bool) {
+	switch s {
+	case "normal":
+		return 400, true
+	case "bold", "bolder":
+		return 700, true
+	case "lighter":
+		return 300, true
+	}
+	w, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	return w, true
+}
+
+// parseLength converts a css length to points, assuming a 12pt font for relative units.
+// Percentages and other units that cannot be converted are not ok.
+func parseLength(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	num := strings.TrimRight(s, "abcdefghijklmnopqrstuvwxyz%")
+	unit := s[len(num):]
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch unit {
+	case "pt":
+		return f, true
+	case "px":
+		return f * 0.75, true
+	case "em", "rem":
+		return f * 12, true
+	case "pc":
+		return f * 12, true
+	case "in":
+		return f * 72, true
+	case "cm":
+		return f * 72 / 2.54, true
+	case "mm":
+		return f * 72 / 25.4, true
+	case "":
+		// unitless lengths are only valid when zero
+		return 0, f == 0
+	}
+	return 0, false
+}
+
+// cssValues splits a css value into its space separated parts, keeping functions such as rgb(...) whole
+func cssValues(s string) []string {
+	var values []string
+	depth, start := 0, -1
+	for i, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ' ' || r == '\t' || r == '\n':
+			if depth == 0 {
+				if start >= 0 {
+					values = append(values, s[start:i])
+				}
+				start = -1
+				continue
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		values = append(values, s[start:])
+	}
+	return values
+}
+
+// boxLeft returns the left side of a padding or margin, from either its longhand or shorthand property
+func boxLeft(decls map[string]string, prop string) float64 {
+	if l, ok := parseLength(decls[prop+"-left"]); ok {
+		return l
+	}
+	values := cssValues(decls[prop])
+	var side string
+	switch len(values) {
+	case 1:
+		side = values[0]
+	case 2, 3:
+		side = values[1]
+	case 4:
+		side = values[3]
+	default:
+		return 0
+	}
+	l, _ := parseLength(side)
+	return l
+}
+
+// borderStyles are the keywords of border-style
+var borderStyles = map[string]bool{
+	"none": true, "hidden": true, "dotted": true, "dashed": true, "solid": true,
+	"double": true, "groove": true, "ridge": true, "inset": true, "outset": true,
+}
+
+// borderWidths are the keywords of border-width, in points
+var borderWidths = map[string]float64{"thin": 0.75, "medium": 2.25, "thick": 3.75}
+
+// parseBorder reads one side of the border from the shorthand, side and longhand properties
+func parseBorder(decls map[string]string, side string) Border {
+	var b Border
+	widthSet := false
+	setWidth := func(v string) bool {
+		w, ok := borderWidths[v]
+		if !ok {
+			w, ok = parseLength(v)
+		}
+		if ok {
+			b.Width, widthSet = w, true
+		}
+		return ok
+	}
+	for _, prop := range []string{"border", "border-" + side} {
+		for _, v := range cssValues(decls[prop]) {
+			if borderStyles[v] {
+				b.Style = v
+			} else if !setWidth(v) {
+				b.Color = v
+			}
+		}
+	}
+	if v := decls["border-"+side+"-style"]; v != "" {
+		b.Style = v
+	}
+	if v := decls["border-"+side+"-width"]; v != "" {
+		setWidth(v)
+	}
+	if v := decls["border-"+side+"-color"]; v != "" {
+		b.Color = v
+	}
+	if b.Style != "" && !widthSet {
+		// the initial width is medium
+		b.Width = borderWidths["medium"]
+	}
+	return b
+}
+
+// parseBackground returns the background color from background-color or the background shorthand
+func parseBackground(decls map[string]string) string {
+	if c := decls["background-color"]; c != "" {
+		return c
+	}
+	for _, v := range cssValues(decls["background"]) {
+		if strings.HasPrefix(v, "url(") || strings.ContainsAny(v[:1], "0123456789") {
+			continue
+		}
+		switch v {
+		case "none", "repeat", "no-repeat", "repeat-x", "repeat-y", "fixed", "scroll",
+			"top", "bottom", "left", "right", "center", "cover", "contain":
+			continue
+		}
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package htmltable
+
+import "testing"
+
+func TestCellStyle(t *testing.T) {
+	p, err := ParseString(testTable3)
+	assertNoError(t, err)
+	cells := p.Meta[0].Cells
+
+	header := cells[1][3].Style
+	assertEqual(t, true, header.Bold())
+	assertEqual(t, "center", header.TextAlign)
+
+	child := cells[6][0].Style
+	assertEqual(t, false, child.Bold())
+	assertEqual(t, "left", child.TextAlign)
+	assertEqual(t, 13.0, child.Indent())
+	assertEqual(t, "rgb(255, 255, 255)", child.Background)
+
+	parent := cells[5][0].Style
+	assertEqual(t, 1.0, parent.Indent())
+
+	total := cells[10][3].Style
+	assertEqual(t, Border{Width: 1, Style: "solid", Color: "rgb(0, 0, 0)"}, total.BorderTop)
+	assertEqual(t, Border{Width: 3, Style: "double", Color: "rgb(0, 0, 0)"}, total.BorderBottom)
+	assertEqual(t, true, total.BorderBottom.Visible())
+	assertEqual(t, false, cells[6][0].Style.BorderTop.Visible())
+}
+
+func TestCellStyleInherited(t *testing.T) {
+	p, err := ParseString(`<table style="font-style: italic">
+		<tr style="font-weight: bold; border-top: thin solid"><td>Total</td><th style="font-weight:normal">1</th><td><i>x</i></td></tr>
+		<tr><th>a</th><td><div style="margin-left: 2em; text-indent: 1em"><b>b</b></div></td><td align="right" style="background: url(x.png) #fff no-repeat">c</td></tr>
+	</table>`)
+	assertNoError(t, err)
+	cells := p.Meta[0].Cells
+	assertEqual(t, true, cells[0][0].Style.Bold())
+	assertEqual(t, true, cells[0][0].Style.Italic())
+	assertEqual(t, Border{Width: 0.75, Style: "solid"}, cells[0][0].Style.BorderTop)
+	assertEqual(t, false, cells[0][1].Style.Bold())
+	assertEqual(t, true, cells[1][0].Style.Bold())
+	assertEqual(t, true, cells[1][1].Style.Bold())
+	assertEqual(t, 24.0, cells[1][1].Style.PaddingLeft)
+	assertEqual(t, 12.0, cells[1][1].Style.TextIndent)
+	assertEqual(t, "right", cells[1][2].Style.TextAlign)
+	assertEqual(t, "#fff", cells[1][2].Style.Background)
+}
+
+func TestParseLength(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"13pt", 13, true},
+		{"4px", 3, true},
+		{"1in", 72, true},
+		{"0", 0, true},
+		{"5", 0, false},
+		{"10%", 0, false},
+		{"auto", 0, false},
+	} {
+		got, ok := parseLength(tc.in)
+		assertEqual(t, tc.want, got)
+		assertEqual(t, tc.ok, ok)
+	}
+}
+
+func TestCSSValues(t *testing.T) {
+	assertEqual(t, []string{"1pt", "solid", "rgb(0, 0, 0)"}, cssValues("1pt  solid rgb(0, 0, 0)"))
+	assertEqual(t, []string(nil), cssValues(""))
+}
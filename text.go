@@ -0,0 +1,231 @@
+package htmltable
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// TextMode selects how the contents of a cell are flattened into its string value
+type TextMode int
+
+const (
+	// TextLegacy trims every text node and joins them with a single space. This is the default.
+	TextLegacy TextMode = iota
+	// TextBlock follows html inline and block semantics, in the manner of a browser's innerText.
+	// Adjacent inline elements are joined without a space unless there is whitespace in the source,
+	// while <br> and block elements such as <p> and <div> produce line breaks.
+	TextBlock
+)
+
+// Whitespace selects how whitespace within text is treated in TextBlock mode
+type Whitespace int
+
+const (
+	// WhitespaceCollapse collapses runs of whitespace into a single space, keeping line breaks. This is the default.
+	WhitespaceCollapse Whitespace = iota
+	// WhitespaceSingleLine collapses line breaks as well, producing a single line of text even from <pre>
+	WhitespaceSingleLine
+	// WhitespacePreserve keeps whitespace within text nodes as it appears in the source
+	WhitespacePreserve
+)
+
+// WithTextMode sets how cell contents are flattened into text
+func WithTextMode(m TextMode) Option {
+	return func(p *Parser) {
+		p.textMode = m
+	}
+}
+
+// WithWhitespace sets how whitespace is treated in TextBlock mode
+func WithWhitespace(w Whitespace) Option {
+	return func(p *Parser) {
+		p.whitespace = w
+	}
+}
+
+// WithNBSPAsSpace treats non-breaking spaces (&nbsp;) as ordinary whitespace in TextBlock mode,
+// so they are collapsed and trimmed along with it
+func WithNBSPAsSpace() Option {
+	return func(p *Parser) {
+		p.nbspAsSpace = true
+	}
+}
+
+// blockElements produce a line break before and after their content
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "caption": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "fieldset": true, "figcaption": true,
+	"figure": true, "footer": true, "form": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true, "main": true,
+	"nav": true, "ol": true, "p": true, "pre": true, "section": true, "table": true,
+	"tr": true, "ul": true,
+}
+
+// skippedElements never contribute text
+var skippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "template": true, "noscript": true,
+}
+
+// textRenderer accumulates the text of a node tree following inline and block semantics.
+//
+// Separators are held as pending until the next piece of text is written,
+// so the output never starts or ends with whitespace.
+type textRenderer struct {
+	p            *Parser
+	whitespace   Whitespace
+	nbspAsSpace  bool
+	footnotes    bool // skip footnote markers, which are kept separately
+	sb           strings.Builder
+	pendingSpace bool
+	pendingBreak int
+	preserve     int // depth of <pre> elements, within which whitespace is kept
+}
+
+// renderText returns the text of the children of n in TextBlock mode
+func (p *Parser) renderText(n *html.Node) string {
+	return (&textRenderer{
+		p:           p,
+		whitespace:  p.whitespace,
+		nbspAsSpace: p.nbspAsSpace,
+		footnotes:   p.footnotes,
+	}).renderChildren(n)
+}
+
+// plainText returns the text of the children of n on a single line, including any footnote markers,
+// regardless of the parser's text settings
+func (p *Parser) plainText(n *html.Node) string {
+	return (&textRenderer{
+		p:           p,
+		whitespace:  WhitespaceSingleLine,
+		nbspAsSpace: true,
+	}).renderChildren(n)
+}
+
+func (tr *textRenderer) renderChildren(n *html.Node) string {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		tr.render(c)
+	}
+	// cell values never have surrounding whitespace, including non-breaking spaces
+	return strings.TrimSpace(tr.sb.String())
+}
+
+func (tr *textRenderer) render(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		tr.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	if skippedElements[n.Data] || tr.p.isHidden(n) {
+		return
+	}
+	if tr.footnotes {
+		if _, ok := tr.p.footnoteMarker(n); ok {
+			return
+		}
+	}
+	switch {
+	case n.Data == "br":
+		tr.lineBreak(true)
+		return
+	case n.Data == "td" || n.Data == "th":
+		tr.pendingSpace = true
+	case blockElements[n.Data]:
+		tr.lineBreak(false)
+		defer tr.lineBreak(false)
+	}
+	if n.Data == "pre" {
+		tr.preserve++
+		defer func() {
+			tr.preserve--
+		}()
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		tr.render(c)
+	}
+}
+
+// lineBreak requests a line break before the next text.
+// Forced breaks, from <br>, accumulate while block boundaries collapse into one.
+func (tr *textRenderer) lineBreak(forced bool) {
+	if tr.whitespace == WhitespaceSingleLine {
+		tr.pendingSpace = true
+		return
+	}
+	if forced {
+		tr.pendingBreak++
+	} else if tr.pendingBreak == 0 {
+		tr.pendingBreak = 1
+	}
+}
+
+// singleLine joins the lines of preserved text with single spaces, for WhitespaceSingleLine within <pre>
+func singleLine(s string) string {
+	var lines []string
+	for _, line := range strings.FieldsFunc(s, func(r rune) bool { return r == '\n' || r == '\r' || r == '\f' }) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// isSpace reports whether r is whitespace to be collapsed
+func (tr *textRenderer) isSpace(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\r', '\f':
+		return true
+	case '\u00a0':
+		return tr.nbspAsSpace
+	}
+	return false
+}
+
+// flush writes any pending separator, ahead of writing more text
+func (tr *textRenderer) flush() {
+	if tr.sb.Len() > 0 {
+		if tr.pendingBreak > 0 {
+			tr.sb.WriteString(strings.Repeat("\n", tr.pendingBreak))
+		} else if tr.pendingSpace {
+			tr.sb.WriteByte(' ')
+		}
+	}
+	tr.pendingBreak = 0
+	tr.pendingSpace = false
+}
+
+// text writes the contents of a text node, collapsing its whitespace unless preserving it.
+// Leading and trailing whitespace becomes a pending space, joining it to neighbouring text.
+func (tr *textRenderer) text(s string) {
+	trimmed := strings.TrimFunc(s, tr.isSpace)
+	if trimmed == "" {
+		if s != "" {
+			tr.pendingSpace = true
+		}
+		return
+	}
+	if strings.TrimLeftFunc(s, tr.isSpace) != s {
+		tr.pendingSpace = true
+	}
+	if tr.preserve > 0 || tr.whitespace == WhitespacePreserve {
+		if tr.whitespace == WhitespaceSingleLine {
+			trimmed = singleLine(trimmed)
+		}
+		tr.flush()
+		tr.sb.WriteString(trimmed)
+	} else {
+		for i, w := range strings.FieldsFunc(trimmed, tr.isSpace) {
+			if i > 0 {
+				tr.pendingSpace = true
+			}
+			tr.flush()
+			tr.sb.WriteString(w)
+		}
+	}
+	if strings.TrimRightFunc(s, tr.isSpace) != s {
+		tr.pendingSpace = true
+	}
+}
@@ -0,0 +1,60 @@
+package htmltable
+
+import "testing"
+
+func TestTextBlock(t *testing.T) {
+	ts, err := NewFromString(testTableText, WithTextMode(TextBlock))
+	assertNoError(t, err)
+	assertEqual(t, *ts[0], Table{
+		{"Market capitalization change.[4]", "$1,234", "a b"},
+		{"line 1\nline 2", "para 1\npara 2\nafter", "x\n\ny"},
+		{"(34,335)", "$\u00a01", "keep   this\n  too"},
+	})
+}
+
+func TestTextLegacy(t *testing.T) {
+	ts, err := NewFromString(testTableText)
+	assertNoError(t, err)
+	assertEqual(t, "Market capitalization change. [4]", (*ts[0])[0][0])
+	assertEqual(t, "$1 ,234", (*ts[0])[0][1])
+}
+
+func TestTextSingleLine(t *testing.T) {
+	ts, err := NewFromString(testTableText, WithTextMode(TextBlock), WithWhitespace(WhitespaceSingleLine), WithNBSPAsSpace())
+	assertNoError(t, err)
+	assertEqual(t, (*ts[0])[1], []string{"line 1 line 2", "para 1 para 2 after", "x y"})
+	assertEqual(t, (*ts[0])[2][1], "$ 1")
+	// <pre> keeps its spaces, but not its line breaks
+	assertEqual(t, (*ts[0])[2][2], "keep   this too")
+}
+
+func TestTextPreserve(t *testing.T) {
+	ts, err := NewFromString(`<table><tr><td>  a   b  <span>c</span></td></tr></table>`,
+		WithTextMode(TextBlock), WithWhitespace(WhitespacePreserve))
+	assertNoError(t, err)
+	assertEqual(t, (*ts[0])[0][0], "a   b c")
+}
+
+const testTableText = `<table>
+<tr>
+	<td>Market capitalization change.<sup class="reference"><a href="#cite_note-4">[4]</a></sup></td>
+	<td><span>$1</span><span>,234</span></td>
+	<td><b>a</b>  <i>b</i></td>
+</tr>
+<tr>
+	<td>line 1<br>line 2</td>
+	<td><p>para 1</p><p>para 2</p>after</td>
+	<td>x<br><br>y</td>
+</tr>
+<tr>
+	<td>
+		<span>(<ix:nonfraction
+			name="upst:InterestIncome"
+			>34,335</ix:nonfraction
+		>)</span>
+	</td>
+	<td>$&nbsp;1&nbsp;</td>
+	<td><pre>keep   this
+  too</pre></td>
+</tr>
+</table>`
@@ -0,0 +1,168 @@
+package htmltable
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Unit is the declared unit and scale of the amounts in a table,
+// e.g. "(in thousands, except per share data)"
+type Unit struct {
+	// Scale multiplies the amounts written in the table to give their actual value, e.g. 1000 for thousands.
+	// It is 1 when no scale was declared.
+	Scale float64
+	// Currency is the ISO 4217 code of the currency, e.g. "USD", or "" when not declared
+	Currency string
+	// Percent is set when the amounts are percentages
+	Percent bool
+	// PerShare is set when the amounts are per share
+	PerShare bool
+	// ExceptPerShare is set when per share amounts are excluded from the scale
+	ExceptPerShare bool
+	// Source is the text the unit was declared in, or "" if none was found
+	Source string
+}
+
+var (
+	// unitRegexp matches a declaration of scale, e.g. "(in thousands)", "$ in millions", "in millions of dollars",
+	// "(000s)" or "$m", but not the words alone, as in "millions of customers"
+	unitRegexp = regexp.MustCompile(`(?im)(?:\bin\s+(?:[$€£¥]\s*)?|\(\s*)(thousands|millions|billions)` + unitEndPattern +
+		`|\b(thousands|millions|billions)\s+of\s+` + currencyNamePattern +
+		`|\(\s*(000s|000's|'000|000)\s*\)|[$€£¥]\s?(000s|000|mm|mn|m|bn|b|k)\b`)
+	// percentRegexp matches a declaration of percentages
+	percentRegexp = regexp.MustCompile(`(?i)\(\s*(?:in\s+)?(?:%|percent|percentages?)\s*\)|\bin\s+percent(?:ages?)?\b`)
+	// perShareRegexp matches per share amounts, noting any exception
+	perShareRegexp = regexp.MustCompile(`(?i)\b(except|excluding|other than)?\s*(?:for\s+)?(?:per[\s-]share|per[\s-]unit|share and per share)`)
+	// sentenceBreakRegexp matches the end of a line or sentence, but not abbreviations such as U.S.
+	sentenceBreakRegexp = regexp.MustCompile(`\n|\.(?:\s+[A-Z(]|\s*$)`)
+	// parenRegexp matches a parenthesised part of a text, where declarations are usually found
+	parenRegexp = regexp.MustCompile(`\([^()]*\)`)
+)
+
+const (
+	// currencyNamePattern matches the currency of amounts, e.g. "U.S. dollars"
+	currencyNamePattern = `(?:u\.?s\.?\s+)?(?:dollars|euros|pounds|yen)\b`
+	// unitEndPattern matches what follows the scale word of a declaration: its currency,
+	// the end of the declaration or an exception
+	unitEndPattern = `(?:\s+of\s+` + currencyNamePattern + `|\s*[,;)]|\s*$|\s+except\b)`
+)
+
+// scaleWords maps the words of a declaration to its scale
+var scaleWords = map[string]float64{
+	"thousands": 1e3, "000s": 1e3, "000's": 1e3, "'000": 1e3, "000": 1e3, "k": 1e3,
+	"millions": 1e6, "mm": 1e6, "mn": 1e6, "m": 1e6,
+	"billions": 1e9, "bn": 1e9, "b": 1e9,
+}
+
+// currencies maps symbols and names to ISO 4217 codes
+var currencies = []struct {
+	pattern *regexp.Regexp
+	code    string
+}{
+	{regexp.MustCompile(`(?i)\$|\bdollars?\b|\busd\b`), "USD"},
+	{regexp.MustCompile(`(?i)€|\beuros?\b|\beur\b`), "EUR"},
+	{regexp.MustCompile(`(?i)£|\bpounds?\b|\bgbp\b`), "GBP"},
+	{regexp.MustCompile(`(?i)¥|\byen\b|\bjpy\b`), "JPY"},
+}
+
+// detectUnit returns the unit declared in text, and whether a declaration was found
+func detectUnit(text string) (Unit, bool) {
+	unit := Unit{Scale: 1}
+	// prefer a parenthesised declaration, falling back to the whole text
+	source := ""
+	for _, paren := range parenRegexp.FindAllString(text, -1) {
+		if unitRegexp.MatchString(paren) || percentRegexp.MatchString(paren) {
+			source = paren
+			break
+		}
+	}
+	if source == "" {
+		loc := unitRegexp.FindStringIndex(text)
+		if loc == nil {
+			loc = percentRegexp.FindStringIndex(text)
+		}
+		if loc == nil {
+			return unit, false
+		}
+		// the declaration is the line or sentence around the match
+		start := 0
+		if breaks := sentenceBreakRegexp.FindAllStringIndex(text[:loc[0]], -1); len(breaks) > 0 {
+			start = breaks[len(breaks)-1][0] + 1
+		}
+		end := len(text)
+		if next := sentenceBreakRegexp.FindStringIndex(text[loc[1]:]); next != nil {
+			end = loc[1] + next[0]
+		}
+		source = strings.TrimSpace(text[start:end])
+	}
+	unit.Source = source
+	if m := unitRegexp.FindStringSubmatch(source); m != nil {
+		word := strings.ToLower(m[1] + m[2] + m[3] + m[4])
+		if scale, ok := scaleWords[word]; ok {
+			unit.Scale = scale
+		}
+	}
+	unit.Percent = percentRegexp.MatchString(source)
+	if m := perShareRegexp.FindStringSubmatch(source); m != nil {
+		if m[1] != "" {
+			unit.ExceptPerShare = true
+		} else {
+			unit.PerShare = true
+		}
+	}
+	for _, c := range currencies {
+		if c.pattern.MatchString(source) {
+			unit.Currency = c.code
+			break
+		}
+	}
+	return unit, true
+}
+
+// tableUnit finds the unit of a table from its caption, the text of its header rows,
+// or the block of text just before it, in that order
+func tableUnit(t Table, meta *TableMeta) Unit {
+	if unit, ok := detectUnit(meta.Caption); ok {
+		return unit
+	}
+	var header []string
+	for _, row := range t[:headerRowCount(t)] {
+		header = append(header, strings.Join(row, "\n"))
+	}
+	if unit, ok := detectUnit(strings.Join(header, "\n")); ok {
+		return unit
+	}
+	blocks := strings.Split(meta.Preceding, "\n")
+	if unit, ok := detectUnit(blocks[len(blocks)-1]); ok {
+		return unit
+	}
+	return Unit{Scale: 1}
+}
+
+// Apply scales v to its actual value
+func (u Unit) Apply(v float64) float64 {
+	if u.Scale == 0 {
+		return v
+	}
+	return v * u.Scale
+}
+
+// scaleFor returns the scale for a value in the row labelled label.
+// Percentages, and per share amounts when excepted, are not scaled.
+func (u Unit) scaleFor(label, value string) float64 {
+	if u.Scale == 0 || strings.Contains(value, "%") {
+		return 1
+	}
+	if u.ExceptPerShare && perShareRegexp.MatchString(label) {
+		return 1
+	}
+	return u.Scale
+}
+
+// WithAutoScale makes Cell.Float and Cell.Int apply the Unit declared for each table,
+// leaving per share amounts unscaled when the declaration excepts them, as well as percentages
+func WithAutoScale() Option {
+	return func(p *Parser) {
+		p.autoScale = true
+	}
+}
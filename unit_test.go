@@ -0,0 +1,90 @@
+package htmltable
+
+import "testing"
+
+func TestDetectUnit(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		want Unit
+		ok   bool
+	}{
+		{"CONSOLIDATED STATEMENTS OF OPERATIONS\n(in thousands, except per share data)", Unit{
+			Scale: 1e3, ExceptPerShare: true, Source: "(in thousands, except per share data)",
+		}, true},
+		{"Revenue ($ in millions)", Unit{Scale: 1e6, Currency: "USD", Source: "($ in millions)"}, true},
+		{"Amounts in billions of U.S. dollars. Unaudited.", Unit{
+			Scale: 1e9, Currency: "USD", Source: "Amounts in billions of U.S. dollars",
+		}, true},
+		{"Segment results (€m)", Unit{Scale: 1e6, Currency: "EUR", Source: "(€m)"}, true},
+		{"Margins (in percent)", Unit{Scale: 1, Percent: true, Source: "(in percent)"}, true},
+		{"Dividends (per share)", Unit{Scale: 1}, false},
+		{"Revenue (b)", Unit{Scale: 1}, false},
+		{"Results in millions of dollars", Unit{Scale: 1e6, Currency: "USD", Source: "Results in millions of dollars"}, true},
+		{"Dollars in thousands", Unit{Scale: 1e3, Currency: "USD", Source: "Dollars in thousands"}, true},
+		{"We serve millions of customers in thousands of towns.", Unit{Scale: 1}, false},
+		{"Prices rose in millions of homes, by thousands", Unit{Scale: 1}, false},
+		{"", Unit{Scale: 1}, false},
+	} {
+		got, ok := detectUnit(tc.text)
+		assertEqual(t, tc.want, got)
+		assertEqual(t, tc.ok, ok)
+	}
+}
+
+func TestTableUnit(t *testing.T) {
+	p, err := ParseString(testTableUnits, WithAutoScale())
+	assertNoError(t, err)
+	assertEqual(t, 3, len(p.Tables))
+
+	m := p.Meta[0]
+	assertEqual(t, "Condensed Consolidated Statements of Operations", m.Heading)
+	assertEqual(t, "Condensed Consolidated Statements of Operations\nUPSTART HOLDINGS, INC.\n(in thousands, except per share data)", m.Preceding)
+	assertEqual(t, 1e3, m.Unit.Scale)
+	assertEqual(t, true, m.Unit.ExceptPerShare)
+	revenue, err := m.Cells[1][1].Int()
+	assertNoError(t, err)
+	assertEqual(t, int64(134_352_000), revenue)
+	// the table itself holds the values as written
+	revenue, err = p.Tables[0].Int(1, 1)
+	assertNoError(t, err)
+	assertEqual(t, int64(134_352), revenue)
+	eps, err := m.Cells[2][1].Float()
+	assertNoError(t, err)
+	assertEqual(t, -0.47, eps)
+
+	assertEqual(t, "Loans ($ in millions)", p.Meta[1].Caption)
+	assertEqual(t, "USD", p.Meta[1].Unit.Currency)
+	assertEqual(t, 1e6, p.Meta[1].Unit.Scale)
+	assertEqual(t, "(in millions)", p.Meta[2].Unit.Source)
+
+	// prose mentioning large numbers, or a declaration before other text, does not set the scale
+	p, err = ParseString(`<p>(in millions)</p><p>We served millions of customers last year.</p>
+		<table><tr><td></td><td>2023</td></tr><tr><td>Customers</td><td>12</td></tr></table>`)
+	assertNoError(t, err)
+	assertEqual(t, Unit{Scale: 1}, p.Meta[0].Unit)
+
+	// without auto scaling, values are as written
+	p, err = ParseString(testTableUnits)
+	assertNoError(t, err)
+	revenue, err = p.Meta[0].Cells[1][1].Int()
+	assertNoError(t, err)
+	assertEqual(t, int64(134_352), revenue)
+}
+
+const testTableUnits = `<body>
+<h2>Condensed Consolidated Statements of Operations</h2>
+<div><p>UPSTART HOLDINGS, INC.</p><p><i>(in thousands, except per share data)</i></p></div>
+<table>
+	<tr><td></td><td>2023</td></tr>
+	<tr><td>Total revenue</td><td>134,352</td></tr>
+	<tr><td>Net loss per share, basic</td><td>(0.47)</td></tr>
+</table>
+<table>
+	<caption>Loans ($ in millions)</caption>
+	<tr><td>Originated</td><td>1,200</td></tr>
+</table>
+<table>
+	<tr><td>Segment</td><td>(in millions)</td></tr>
+	<tr><td>Lending</td><td>5</td></tr>
+</table>
+</body>`
@@ -0,0 +1,254 @@
+package htmltable
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError is a value that could not be converted into a struct field by Unmarshal
+type FieldError struct {
+	Row   int
+	Col   int
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("row %d col %d: field %s: cannot use %q: %s", e.Row, e.Col, e.Field, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalErrors collects every FieldError of a call to Unmarshal
+type UnmarshalErrors []*FieldError
+
+func (e UnmarshalErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// Unmarshal converts each row of t below its header into an element of dst,
+// which must be a pointer to a slice of structs or struct pointers. Blank rows are skipped.
+//
+// Fields are matched to columns by their `htmltable:"..."` tag, holding either a header name,
+// looked up as Table.ColumnIndex does, or a column index such as "#3". Untagged fields are matched
+// by their name when a column has it, and fields tagged "-" are ignored.
+//
+// Values are converted to strings, bools, integers and floats (parsed with ParseNumber, except that percentages
+// are fractions, e.g. 0.125 for "12.5%", as in WriteXLSX and WriteParquet),
+// time.Time (parsed with ParseDate) and implementations of encoding.TextUnmarshaler, including big.Float and big.Rat,
+// as well as pointers to these, which are left nil for blank values.
+// All conversion failures are returned together as UnmarshalErrors.
+func Unmarshal(t *Table, dst any, opts ...LookupOption) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("unmarshal: dst must be a pointer to a slice, got %T", dst)
+	}
+	slice := v.Elem()
+	elem := slice.Type().Elem()
+	structType := elem
+	if elem.Kind() == reflect.Pointer {
+		structType = elem.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal: dst must be a slice of structs, got %T", dst)
+	}
+
+	fields, err := t.fieldColumns(structType, opts)
+	if err != nil {
+		return err
+	}
+	l := newLookup(*t, opts)
+	var errs UnmarshalErrors
+	for i := l.headerRows; i < len(*t); i++ {
+		row := (*t)[i]
+		if !hasText(row) {
+			continue
+		}
+		item := reflect.New(structType).Elem()
+		for _, f := range fields {
+			value := ""
+			if f.col < len(row) {
+				value = row[f.col]
+			}
+			if err := setField(item.FieldByIndex(f.index), value); err != nil {
+				errs = append(errs, &FieldError{Row: i, Col: f.col, Field: f.name, Value: value, Err: err})
+			}
+		}
+		if elem.Kind() == reflect.Pointer {
+			item = item.Addr()
+		}
+		slice.Set(reflect.Append(slice, item))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// fieldColumn is a struct field along with the column it is read from
+type fieldColumn struct {
+	name  string
+	index []int
+	col   int
+}
+
+// fieldColumns matches the exported fields of structType to columns of the table
+func (t Table) fieldColumns(structType reflect.Type, opts []LookupOption) ([]fieldColumn, error) {
+	var fields []fieldColumn
+	for i := 0; i < structType.NumField(); i++ {
+		sf := structType.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, tagged := sf.Tag.Lookup("htmltable")
+		if tag == "-" {
+			continue
+		}
+		f := fieldColumn{name: sf.Name, index: sf.Index}
+		switch {
+		case strings.HasPrefix(tag, "#"):
+			col, err := strconv.Atoi(tag[1:])
+			if err != nil || col < 0 {
+				return nil, fmt.Errorf("unmarshal: field %s: invalid column index %q", sf.Name, tag)
+			}
+			f.col = col
+		case tagged && tag != "":
+			col, err := t.ColumnIndex(tag, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("unmarshal: field %s: %w", sf.Name, err)
+			}
+			f.col = col
+		default:
+			col, err := t.ColumnIndex(sf.Name, opts...)
+			if err != nil {
+				continue
+			}
+			f.col = col
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// setField converts s into field v
+func setField(v reflect.Value, s string) error {
+	s = strings.TrimSpace(s)
+	if v.Kind() == reflect.Pointer {
+		if s == "" {
+			return nil
+		}
+		ptr := reflect.New(v.Type().Elem())
+		if err := setField(ptr.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+	// time.Time is a TextUnmarshaler too, but only of RFC 3339
+	if v.Type() == timeType {
+		if s == "" {
+			return nil
+		}
+		tm, ok := ParseDate(s)
+		if !ok {
+			return fmt.Errorf("not a date")
+		}
+		v.Set(reflect.ValueOf(tm))
+		return nil
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if s == "" {
+			return nil
+		}
+		u := v.Addr().Interface().(encoding.TextUnmarshaler)
+		err := u.UnmarshalText([]byte(s))
+		if err != nil {
+			// decimal types expect plain numbers, without currency symbols or separators
+			if clean, ok := fractionText(s); ok {
+				err = u.UnmarshalText([]byte(clean))
+			}
+		}
+		return err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		if s == "" {
+			return nil
+		}
+		b, ok := parseBool(s)
+		if !ok {
+			return fmt.Errorf("not a boolean")
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if s == "" {
+			return nil
+		}
+		f, ok := fractionNumber(s)
+		if !ok {
+			return fmt.Errorf("not a number")
+		}
+		i, err := toInt(f, 0, 0)
+		if err != nil || v.OverflowInt(i) {
+			return fmt.Errorf("not an integer of %s", v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if s == "" {
+			return nil
+		}
+		f, ok := fractionNumber(s)
+		if !ok {
+			return fmt.Errorf("not a number")
+		}
+		i, err := toInt(f, 0, 0)
+		if err != nil || i < 0 || v.OverflowUint(uint64(i)) {
+			return fmt.Errorf("not an integer of %s", v.Type())
+		}
+		v.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if s == "" {
+			return nil
+		}
+		f, ok := fractionNumber(s)
+		if !ok {
+			return fmt.Errorf("not a number")
+		}
+		v.SetFloat(f)
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// parseBool reads the ways tables write yes and no, including checkboxes
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "y", "x", "1", "✓", "✔", "☑", "☒", "on":
+		return true, true
+	case "false", "no", "n", "0", "☐", "off", "-", "—":
+		return false, true
+	}
+	return false, false
+}
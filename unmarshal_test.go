@@ -0,0 +1,88 @@
+package htmltable
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestUnmarshal(t *testing.T) {
+	ts, err := NewFromString(testTable2)
+	assertNoError(t, err)
+
+	type change struct {
+		Date    time.Time
+		Added   string `htmltable:"Added Ticker"`
+		Removed string `htmltable:"#3"`
+		Reason  string
+		Note    string `htmltable:"-"`
+		ignored string
+	}
+	var changes []change
+	assertNoError(t, Unmarshal(ts[0], &changes))
+	assertEqual(t, 2, len(changes))
+	assertEqual(t, change{
+		Date:    time.Date(2022, time.June, 21, 0, 0, 0, 0, time.UTC),
+		Added:   "ON",
+		Removed: "IPGP",
+		Reason:  "Market capitalization change. [4]",
+	}, changes[1])
+
+	var ptrs []*change
+	assertNoError(t, Unmarshal(ts[0], &ptrs))
+	assertEqual(t, "KDP", ptrs[0].Added)
+
+	var missing []struct {
+		Price float64 `htmltable:"Price"`
+	}
+	err = Unmarshal(ts[0], &missing)
+	assertEqual(t, true, errors.Is(err, ErrNotFound))
+	assertEqual(t, true, Unmarshal(ts[0], changes) != nil)
+}
+
+func TestUnmarshalTypes(t *testing.T) {
+	ts, err := NewFromString(`<table>
+		<tr><th>Name</th><th>Shares</th><th>Price</th><th>Active</th><th>Weight</th><th>Exact</th></tr>
+		<tr><td>A</td><td>1,200</td><td>$ 10.50</td><td>Yes</td><td>12.5%</td><td>(1,234.5)</td></tr>
+		<tr><td></td><td></td><td></td><td></td><td></td><td></td></tr>
+		<tr><td>B</td><td>—</td><td>n/a</td><td>x</td><td></td><td>0.1</td></tr>
+	</table>`)
+	assertNoError(t, err)
+
+	type holding struct {
+		Name   string
+		Shares int64
+		Price  *float64
+		Active bool
+		Weight float32
+		Exact  *big.Rat
+	}
+	var holdings []holding
+	err = Unmarshal(ts[0], &holdings)
+	var errs UnmarshalErrors
+	assertEqual(t, true, errors.As(err, &errs))
+	assertEqual(t, 1, len(errs))
+	assertEqual(t, 3, errs[0].Row)
+	assertEqual(t, 2, errs[0].Col)
+	assertEqual(t, `row 3 col 2: field Price: cannot use "n/a": not a number`, errs[0].Error())
+
+	assertEqual(t, 2, len(holdings))
+	assertEqual(t, int64(1200), holdings[0].Shares)
+	assertEqual(t, 10.5, *holdings[0].Price)
+	assertEqual(t, true, holdings[0].Active)
+	// percentages are fractions, as in the exports
+	assertEqual(t, float32(0.125), holdings[0].Weight)
+	assertEqual(t, "-2469/2", holdings[0].Exact.String())
+	assertEqual(t, int64(0), holdings[1].Shares)
+	assertEqual(t, (*float64)(nil), holdings[1].Price)
+	assertEqual(t, true, holdings[1].Active)
+	assertEqual(t, "1/10", holdings[1].Exact.String())
+
+	var rates []struct {
+		Rate *big.Rat
+	}
+	assertNoError(t, Unmarshal(&Table{{"Rate"}, {"(2.5%)"}, {"1,250%"}}, &rates))
+	assertEqual(t, "-1/40", rates[0].Rate.String())
+	assertEqual(t, "25/2", rates[1].Rate.String())
+}
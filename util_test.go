@@ -3,6 +3,8 @@ package htmltable
 import (
 	"reflect"
 	"testing"
+
+	"golang.org/x/net/html"
 )
 
 func assertError(t *testing.T, err error) {
@@ -30,3 +32,15 @@ func assertEqual(t *testing.T, a, b any) {
 		t.Errorf("%#v (expected) != %#v (got)", a, b)
 	}
 }
+
+// findAll returns the elements below n with the given tag, in document order
+func findAll(n *html.Node, tag string) []*html.Node {
+	var found []*html.Node
+	if n.Type == html.ElementNode && n.Data == tag {
+		found = append(found, n)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		found = append(found, findAll(c, tag)...)
+	}
+	return found
+}
@@ -0,0 +1,104 @@
+package htmltable
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Visibility is a set of flags choosing which mechanisms for hiding content are honoured.
+//
+// Hidden elements are skipped during parsing along with all of their descendants,
+// so a hidden row, tbody, table or wrapping div suppresses every cell within it.
+// Content hidden within a cell is only skipped with VisibilityInline.
+// Descendants re-establishing visibility:visible are not taken into account.
+type Visibility uint
+
+const (
+	// VisibilityDisplayNone hides elements styled with display:none
+	VisibilityDisplayNone Visibility = 1 << iota
+	// VisibilityHidden hides elements styled with visibility:hidden or visibility:collapse
+	VisibilityHidden
+	// VisibilityHiddenAttr hides elements with the html hidden attribute
+	VisibilityHiddenAttr
+	// VisibilityAriaHidden hides elements with aria-hidden="true"
+	VisibilityAriaHidden
+	// VisibilityZeroFontSize hides elements styled with a font-size of zero
+	VisibilityZeroFontSize
+	// VisibilityStylesheets applies rules from <style> blocks in the document, in addition to inline styles
+	VisibilityStylesheets
+	// VisibilityContainers hides rows, tables and any other elements around cells, as well as the cells themselves
+	VisibilityContainers
+	// VisibilityInline hides content within cells, such as a span styled with display:none
+	VisibilityInline
+)
+
+const (
+	// DefaultVisibility honours inline display:none on cells and the elements around them,
+	// and is used unless WithVisibility is given
+	DefaultVisibility = VisibilityDisplayNone | VisibilityContainers
+	// FullVisibility honours every supported hiding mechanism
+	FullVisibility = VisibilityDisplayNone | VisibilityHidden | VisibilityHiddenAttr |
+		VisibilityAriaHidden | VisibilityZeroFontSize | VisibilityStylesheets | VisibilityContainers | VisibilityInline
+)
+
+// WithVisibility sets the policy used to decide which content is hidden
+func WithVisibility(v Visibility) Option {
+	return func(p *Parser) {
+		p.visibility = v
+	}
+}
+
+// isHidden reports whether element n itself is hidden under the parser's visibility policy
+func (p *Parser) isHidden(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	v := p.visibility
+	switch p.kindOf(n) {
+	case kindCell, kindTerm, kindDefinition:
+	default:
+		if p.inCell && v&VisibilityInline == 0 || !p.inCell && v&VisibilityContainers == 0 {
+			return false
+		}
+	}
+	if v&VisibilityHiddenAttr != 0 && hasAttr(n, "hidden") {
+		return true
+	}
+	if v&VisibilityAriaHidden != 0 && strings.EqualFold(strings.TrimSpace(getAttr(n, "aria-hidden")), "true") {
+		return true
+	}
+	style := p.styles.style(n)
+	if v&VisibilityDisplayNone != 0 && style["display"] == "none" {
+		return true
+	}
+	if v&VisibilityHidden != 0 && (style["visibility"] == "hidden" || style["visibility"] == "collapse") {
+		return true
+	}
+	if v&VisibilityZeroFontSize != 0 && isZeroLength(style["font-size"]) {
+		return true
+	}
+	return false
+}
+
+// isZeroLength reports whether a css length such as "0", "0px" or "0.0em" is zero
+func isZeroLength(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	num := strings.TrimRight(s, "abcdefghijklmnopqrstuvwxyz%")
+	f, err := strconv.ParseFloat(num, 64)
+	return err == nil && f == 0
+}
+
+// WithHiddenIncluded keeps hidden tables and rows in the results rather than skipping them,
+// recording them as hidden in the TableMeta instead.
+//
+// Hidden cells are still skipped, as keeping them would misalign the columns of the table.
+func WithHiddenIncluded() Option {
+	return func(p *Parser) {
+		p.includeHidden = true
+	}
+}
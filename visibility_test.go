@@ -0,0 +1,127 @@
+package htmltable
+
+import "testing"
+
+func TestVisibilityDefault(t *testing.T) {
+	ts, err := NewFromString(testTableHidden)
+	assertNoError(t, err)
+	assertEqual(t, len(ts), 2)
+	// hidden rows and tables are dropped by default
+	assertEqual(t, *ts[0], Table{
+		{"a", "b", "c", "d", "e", "f"},
+		{"1", "2", "3", "4", "5", "6"},
+	})
+
+	const inline = `<table><tr><td>a<span style="display:none">b</span></td></tr></table>`
+	ts, err = NewFromString(inline)
+	assertNoError(t, err)
+	assertEqual(t, Table{{"a b"}}, *ts[0])
+	ts, err = NewFromString(inline, WithVisibility(DefaultVisibility|VisibilityInline))
+	assertNoError(t, err)
+	assertEqual(t, Table{{"a"}}, *ts[0])
+}
+
+func TestVisibilityFull(t *testing.T) {
+	ts, err := NewFromString(testTableHidden, WithVisibility(FullVisibility))
+	assertNoError(t, err)
+	assertEqual(t, len(ts), 1)
+	assertEqual(t, *ts[0], Table{
+		{"a", ""},
+		{"1"},
+	})
+}
+
+func TestVisibilityInlineOnly(t *testing.T) {
+	ts, err := NewFromString(testTableHidden, WithVisibility(FullVisibility&^VisibilityStylesheets))
+	assertNoError(t, err)
+	assertEqual(t, len(ts), 2)
+	assertEqual(t, *ts[0], Table{
+		{"a", "e", "f"},
+		{"1", "5", "6"},
+	})
+}
+
+func TestIsZeroLength(t *testing.T) {
+	assertEqual(t, true, isZeroLength("0"))
+	assertEqual(t, true, isZeroLength("0.0pt"))
+	assertEqual(t, true, isZeroLength("0%"))
+	assertEqual(t, false, isZeroLength("10pt"))
+	assertEqual(t, false, isZeroLength(""))
+	assertEqual(t, false, isZeroLength("inherit"))
+}
+
+const testTableHidden = `<html>
+<head>
+<style>
+	/* hidden by class */
+	.sr-only { display: none }
+	table.offscreen td.x, #gone { visibility: hidden; }
+	@media print { td { display: none } }
+</style>
+</head>
+<body>
+<table>
+	<tr><td>a</td><td style="visibility:hidden">b</td><td hidden>c</td><td aria-hidden="true">d</td><td class="sr-only">e</td><td><span id="gone">f</span></td></tr>
+	<tr style="display: none"><td>hidden row</td></tr>
+	<tr><td>1</td><td style="visibility:hidden">2</td><td hidden>3</td><td aria-hidden="true">4</td><td class="sr-only">5</td><td id="gone">6</td></tr>
+</table>
+<table class="offscreen">
+	<tr><td class="x">x</td></tr>
+	<tr><td style="font-size: 0">y</td></tr>
+</table>
+<table style="display:none">
+	<tr><td>z</td></tr>
+</table>
+</body>
+</html>`
+
+func TestVisibilityInherited(t *testing.T) {
+	ts, err := NewFromString(testTableHiddenAncestors)
+	assertNoError(t, err)
+	assertEqual(t, len(ts), 1)
+	assertEqual(t, *ts[0], Table{
+		{"a", "b"},
+		{"3", "4"},
+	})
+}
+
+func TestVisibilityMarked(t *testing.T) {
+	p, err := ParseString(testTableHiddenAncestors, WithHiddenIncluded())
+	assertNoError(t, err)
+	assertEqual(t, len(p.Tables), 3)
+	assertEqual(t, *p.Tables[0], Table{
+		{"a", "b"},
+		{"1", "2"},
+		{"x", "y"},
+		{"3", "4"},
+	})
+	for i, want := range []TableMeta{
+		{Index: 0, HiddenRows: []int{1, 2}},
+		{Index: 1, Hidden: true, HiddenRows: []int{0}},
+		{Index: 2, Hidden: true, HiddenRows: []int{0}},
+	} {
+		assertEqual(t, p.Meta[i].Index, want.Index)
+		assertEqual(t, p.Meta[i].Hidden, want.Hidden)
+		assertEqual(t, p.Meta[i].HiddenRows, want.HiddenRows)
+	}
+	assertEqual(t, *p.Tables[2], Table{{"z"}})
+}
+
+const testTableHiddenAncestors = `<body>
+<table>
+	<tbody>
+		<tr><td>a</td><td>b</td></tr>
+	</tbody>
+	<tbody style="display: none">
+		<tr><td>1</td><td>2</td></tr>
+		<tr><td>x</td><td>y</td></tr>
+	</tbody>
+	<tbody>
+		<tr><td>3</td><td>4</td><td style="display:none">5</td></tr>
+	</tbody>
+</table>
+<div style="display:none">
+	<table><tr><td>hidden</td></tr></table>
+</div>
+<table style="display:none"><tr><td>z</td></tr></table>
+</body>`
@@ -0,0 +1,370 @@
+package htmltable
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xlsxStyle is a cell format of a workbook, as an index into its cellXfs
+type xlsxStyle struct {
+	numFmt int
+	bold   bool
+}
+
+// xlsxStyles collects the cell formats used by the sheets of a workbook
+type xlsxStyles struct {
+	formats []string // custom number formats, with ids from 164
+	styles  []xlsxStyle
+	index   map[xlsxStyle]int
+}
+
+func newXLSXStyles() *xlsxStyles {
+	return &xlsxStyles{
+		styles: []xlsxStyle{{}},
+		index:  map[xlsxStyle]int{{}: 0},
+	}
+}
+
+// style returns the index of the cell format with the number format code and weight
+func (s *xlsxStyles) style(format string, bold bool) int {
+	st := xlsxStyle{bold: bold}
+	if format != "" {
+		st.numFmt = 164 + len(s.formats)
+		for i, f := range s.formats {
+			if f == format {
+				st.numFmt = 164 + i
+			}
+		}
+		if st.numFmt == 164+len(s.formats) {
+			s.formats = append(s.formats, format)
+		}
+	}
+	if i, ok := s.index[st]; ok {
+		return i
+	}
+	s.index[st] = len(s.styles)
+	s.styles = append(s.styles, st)
+	return len(s.styles) - 1
+}
+
+// numberFormat returns the value of a number as written in a table, and the Excel number format that
+// displays it the same way: with thousands separators when it has them, its decimals, currency symbol,
+// percent sign and parentheses for negative amounts.
+// Digits with leading zeros, such as codes, are not numbers, as Excel would drop the zeros.
+func numberFormat(s string) (float64, string, bool) {
+	f, ok := fractionNumber(s)
+	if !ok {
+		return 0, "", false
+	}
+	s = strings.TrimSpace(s)
+	if numberDashes[s] {
+		return 0, `0;(0);"` + s + `"`, true
+	}
+	if clean, _ := cleanNumber(s); hasLeadingZero(clean) {
+		return 0, "", false
+	}
+	format := "0"
+	if strings.Contains(s, ",") {
+		format = "#,##0"
+	}
+	if d := numberDecimals(s); d > 0 {
+		format += "." + strings.Repeat("0", d)
+	}
+	if strings.Contains(s, "%") {
+		format += "%"
+	}
+	for _, symbol := range []string{"$", "€", "£", "¥"} {
+		if strings.Contains(s, symbol) {
+			format = `"` + symbol + `"` + format
+			break
+		}
+	}
+	if strings.Contains(s, "(") {
+		format += ";(" + format + ")"
+	}
+	return f, format, true
+}
+
+// hasLeadingZero reports whether a cleaned number has a zero before other digits, e.g. "007" but not "0.7"
+func hasLeadingZero(clean string) bool {
+	clean = strings.TrimPrefix(clean, "-")
+	return len(clean) > 1 && clean[0] == '0' && clean[1] != '.'
+}
+
+// xlsxSheet is a worksheet being written
+type xlsxSheet struct {
+	name string
+	data []byte
+}
+
+// sheet writes the worksheet of table t, with merges from the Cells of the TableMeta of the options when set
+func (s *xlsxStyles) sheet(t Table, o *renderOptions) []byte {
+	width := columnCount(t)
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if width > 0 {
+		buf.WriteString("<cols>")
+		for col := 0; col < width; col++ {
+			w := 8
+			for r, row := range t {
+				if col < len(row) && o.spans(r, col) == [2]int{1, 1} {
+					if tw := textWidth(row[col]) + 2; tw > w {
+						w = tw
+					}
+				}
+			}
+			if w > 60 {
+				w = 60
+			}
+			fmt.Fprintf(&buf, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, col+1, col+1, w)
+		}
+		buf.WriteString("</cols>")
+	}
+	buf.WriteString("<sheetData>")
+	var merges []string
+	for r, row := range t {
+		fmt.Fprintf(&buf, `<row r="%d">`, r+1)
+		for c, value := range row {
+			if cell := o.cell(r, c); cell != nil && (cell.Row != r || cell.Col != c) {
+				// covered by a merge
+				continue
+			}
+			if spans := o.spans(r, c); spans != [2]int{1, 1} {
+				merges = append(merges, cellRef(r, c)+":"+cellRef(r+spans[0]-1, c+spans[1]-1))
+			}
+			header := r < o.headerRows
+			if value == "" && !header {
+				continue
+			}
+			if f, format, ok := numberFormat(value); ok && !header {
+				fmt.Fprintf(&buf, `<c r="%s" s="%d"><v>%s</v></c>`, cellRef(r, c), s.style(format, false),
+					strconv.FormatFloat(f, 'g', -1, 64))
+				continue
+			}
+			style := 0
+			if header {
+				style = s.style("", true)
+			}
+			fmt.Fprintf(&buf, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, cellRef(r, c), style)
+			xmlEscape(&buf, value)
+			buf.WriteString("</t></is></c>")
+		}
+		buf.WriteString("</row>")
+	}
+	buf.WriteString("</sheetData>")
+	if len(merges) > 0 {
+		fmt.Fprintf(&buf, `<mergeCells count="%d">`, len(merges))
+		for _, ref := range merges {
+			fmt.Fprintf(&buf, `<mergeCell ref="%s"/>`, ref)
+		}
+		buf.WriteString("</mergeCells>")
+	}
+	buf.WriteString("</worksheet>")
+	return buf.Bytes()
+}
+
+// spans returns the number of rows and columns covered by the cell at row r and column c when it starts there
+func (o *renderOptions) spans(r, c int) [2]int {
+	cell := o.cell(r, c)
+	if cell == nil {
+		return [2]int{1, 1}
+	}
+	rows, cols := 1, 1
+	for o.cell(r+rows, c) == cell {
+		rows++
+	}
+	for o.cell(r, c+cols) == cell {
+		cols++
+	}
+	return [2]int{rows, cols}
+}
+
+// cellRef returns the A1 reference of row r and column c, both from 0
+func cellRef(r, c int) string {
+	name := ""
+	for c++; c > 0; c = (c - 1) / 26 {
+		name = string(rune('A'+(c-1)%26)) + name
+	}
+	return name + strconv.Itoa(r+1)
+}
+
+// xmlEscape writes s escaped for xml text, dropping the control characters xml does not allow
+func xmlEscape(w io.Writer, s string) {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+	// writing to a bytes.Buffer does not fail
+	_ = xml.EscapeText(w, []byte(s))
+}
+
+// sheetName reduces name to the characters and length Excel allows in a sheet name
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+// WriteXLSX writes the table to w as an Excel workbook of a single sheet.
+// The header rows are bold, numbers are written as numbers formatted as in the table,
+// and with MergeSpans the cells spanning several rows or columns become merged cells.
+func (t Table) WriteXLSX(w io.Writer, opts ...RenderOption) error {
+	o := newRenderOptions(t, opts)
+	styles := newXLSXStyles()
+	data := styles.sheet(t, o)
+	return writeWorkbook(w, styles, []xlsxSheet{{name: "Sheet1", data: data}})
+}
+
+// WriteXLSX writes every parsed table to w as a sheet of an Excel workbook, named by TableNames,
+// with merged cells for the cells spanning several rows or columns. Sheets are written as Table.WriteXLSX does.
+func (p *Parser) WriteXLSX(w io.Writer) error {
+	styles := newXLSXStyles()
+	var sheets []xlsxSheet
+	used := map[string]bool{}
+	for i, name := range p.TableNames() {
+		var opts []RenderOption
+		if i < len(p.Meta) {
+			opts = append(opts, MergeSpans(p.Meta[i]))
+		}
+		o := newRenderOptions(*p.Tables[i], opts)
+		name = sheetName(name)
+		base := []rune(name)
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			// names differing only in case clash in Excel too
+			suffix := "-" + strconv.Itoa(n)
+			if len(base) > 31-len(suffix) {
+				base = base[:31-len(suffix)]
+			}
+			name = string(base) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		sheets = append(sheets, xlsxSheet{name: name, data: styles.sheet(*p.Tables[i], o)})
+	}
+	if len(sheets) == 0 {
+		// a workbook needs at least one sheet
+		sheets = append(sheets, xlsxSheet{name: "Sheet1", data: styles.sheet(nil, newRenderOptions(nil, nil))})
+	}
+	return writeWorkbook(w, styles, sheets)
+}
+
+// writeWorkbook writes the package of a workbook with the sheets to w
+func writeWorkbook(w io.Writer, styles *xlsxStyles, sheets []xlsxSheet) error {
+	z := zip.NewWriter(w)
+	var buf bytes.Buffer
+	add := func(name string, data []byte) error {
+		f, err := z.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	}
+
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range sheets {
+		fmt.Fprintf(&buf, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	buf.WriteString(`</Types>`)
+	if err := add("[Content_Types].xml", buf.Bytes()); err != nil {
+		return err
+	}
+
+	buf.Reset()
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`)
+	if err := add("_rels/.rels", buf.Bytes()); err != nil {
+		return err
+	}
+
+	buf.Reset()
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range sheets {
+		buf.WriteString(`<sheet name="`)
+		xmlEscape(&buf, sheet.name)
+		fmt.Fprintf(&buf, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	buf.WriteString(`</sheets></workbook>`)
+	if err := add("xl/workbook.xml", buf.Bytes()); err != nil {
+		return err
+	}
+
+	buf.Reset()
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range sheets {
+		fmt.Fprintf(&buf, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&buf, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+	buf.WriteString(`</Relationships>`)
+	if err := add("xl/_rels/workbook.xml.rels", buf.Bytes()); err != nil {
+		return err
+	}
+
+	buf.Reset()
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(styles.formats) > 0 {
+		fmt.Fprintf(&buf, `<numFmts count="%d">`, len(styles.formats))
+		for i, format := range styles.formats {
+			fmt.Fprintf(&buf, `<numFmt numFmtId="%d" formatCode="`, 164+i)
+			xmlEscape(&buf, format)
+			buf.WriteString(`"/>`)
+		}
+		buf.WriteString(`</numFmts>`)
+	}
+	buf.WriteString(`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	fmt.Fprintf(&buf, `<cellXfs count="%d">`, len(styles.styles))
+	for _, st := range styles.styles {
+		font := 0
+		if st.bold {
+			font = 1
+		}
+		fmt.Fprintf(&buf, `<xf numFmtId="%d" fontId="%d" fillId="0" borderId="0" xfId="0"`, st.numFmt, font)
+		if st.numFmt != 0 {
+			buf.WriteString(` applyNumberFormat="1"`)
+		}
+		if st.bold {
+			buf.WriteString(` applyFont="1"`)
+		}
+		buf.WriteString(`/>`)
+	}
+	buf.WriteString(`</cellXfs><cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles></styleSheet>`)
+	if err := add("xl/styles.xml", buf.Bytes()); err != nil {
+		return err
+	}
+
+	for i, sheet := range sheets {
+		if err := add(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.data); err != nil {
+			return err
+		}
+	}
+	return z.Close()
+}
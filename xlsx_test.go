@@ -0,0 +1,106 @@
+package htmltable
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readZip returns the files of a zip archive by name, checking that each xml file is well formed
+func readZip(t *testing.T, data []byte) map[string]string {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assertNoError(t, err)
+	files := map[string]string{}
+	for _, f := range r.File {
+		rc, err := f.Open()
+		assertNoError(t, err)
+		content, err := io.ReadAll(rc)
+		assertNoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+		d := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			_, err := d.Token()
+			if err == io.EOF {
+				break
+			}
+			assertNoError(t, err)
+		}
+	}
+	return files
+}
+
+func TestNumberFormat(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		value  float64
+		format string
+	}{
+		{"1,234", 1234, "#,##0"},
+		{"$ 10.50", 10.5, `"$"0.00`},
+		{"$ 1,010.50", 1010.5, `"$"#,##0.00`},
+		{"(3,050)", -3050, "#,##0;(#,##0)"},
+		{"(30)", -30, "0;(0)"},
+		{"12.5%", 0.125, "0.0%"},
+		{"—", 0, `0;(0);"—"`},
+		{"2022", 2022, "0"},
+		{"0", 0, "0"},
+		{"0.25", 0.25, "0.00"},
+	} {
+		value, format, ok := numberFormat(tc.in)
+		assertEqual(t, true, ok)
+		assertEqual(t, tc.value, value)
+		assertEqual(t, tc.format, format)
+	}
+	for _, s := range []string{"n/a", "0001234", "007", "(01)"} {
+		_, _, ok := numberFormat(s)
+		assertEqual(t, false, ok)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	p, err := ParseString(testTable2 + `<table>
+		<caption>Amounts</caption>
+		<tr><th>Item</th><th>2023</th></tr>
+		<tr><td>Revenue &amp; other</td><td>$ 1,200.50</td></tr>
+		<tr><td>Loss</td><td>(30)</td></tr>
+	</table>`)
+	assertNoError(t, err)
+
+	var buf bytes.Buffer
+	assertNoError(t, p.WriteXLSX(&buf))
+	files := readZip(t, buf.Bytes())
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		_, ok := files[name]
+		assertEqual(t, true, ok)
+	}
+	assertEqual(t, true, strings.Contains(files["xl/workbook.xml"], `<sheet name="table-0" sheetId="1" r:id="rId1"/><sheet name="amounts" sheetId="2" r:id="rId2"/>`))
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assertEqual(t, true, strings.Contains(sheet, `<mergeCells count="4"><mergeCell ref="A1:A2"/><mergeCell ref="B1:C1"/><mergeCell ref="D1:E1"/><mergeCell ref="F1:F2"/></mergeCells>`))
+	assertEqual(t, true, strings.Contains(sheet, `<row r="2"><c r="B2" s="1" t="inlineStr"><is><t xml:space="preserve">Ticker</t></is></c>`))
+
+	sheet = files["xl/worksheets/sheet2.xml"]
+	assertEqual(t, true, strings.Contains(sheet, `<t xml:space="preserve">Revenue &amp; other</t>`))
+	assertEqual(t, true, strings.Contains(sheet, `<c r="B1" s="1" t="inlineStr"><is><t xml:space="preserve">2023</t></is></c>`))
+	assertEqual(t, true, strings.Contains(sheet, `<c r="B2" s="2"><v>1200.5</v></c>`))
+	assertEqual(t, true, strings.Contains(sheet, `<c r="B3" s="3"><v>-30</v></c>`))
+	styles := files["xl/styles.xml"]
+	assertEqual(t, true, strings.Contains(styles, `<numFmt numFmtId="164" formatCode="&#34;$&#34;#,##0.00"/><numFmt numFmtId="165" formatCode="0;(0)"/>`))
+	assertEqual(t, true, strings.Contains(styles, `<cellXfs count="4"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>`))
+
+	buf.Reset()
+	assertNoError(t, Table{{"a"}}.WriteXLSX(&buf, RenderHeaderRows(0)))
+	files = readZip(t, buf.Bytes())
+	assertEqual(t, true, strings.Contains(files["xl/worksheets/sheet1.xml"], `<c r="A1" s="0" t="inlineStr">`))
+}
+
+func TestCellRef(t *testing.T) {
+	assertEqual(t, "A1", cellRef(0, 0))
+	assertEqual(t, "Z3", cellRef(2, 25))
+	assertEqual(t, "AA1", cellRef(0, 26))
+	assertEqual(t, "BA10", cellRef(9, 52))
+}